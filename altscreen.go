@@ -0,0 +1,24 @@
+package ansi
+
+// Enter Alternate Screen	<ESC>[?1049h
+// Exit Alternate Screen	<ESC>[?1049l
+// Entering saves the cursor and switches to a blank screen,
+// exiting returns to the original screen and cursor.
+var EnterAltScreen = []byte{Esc, '[', '?', '1', '0', '4', '9', 'h'}
+var ExitAltScreen = []byte{Esc, '[', '?', '1', '0', '4', '9', 'l'}
+
+func (a *Ansi) EnterAltScreen() {
+	a.Write(EnterAltScreen)
+}
+
+func (a *Ansi) ExitAltScreen() {
+	a.Write(ExitAltScreen)
+}
+
+// WithAltScreen runs fn on the alternate screen, returning to the
+// original screen afterwards, even if fn panics
+func (a *Ansi) WithAltScreen(fn func() error) error {
+	a.EnterAltScreen()
+	defer a.ExitAltScreen()
+	return fn()
+}
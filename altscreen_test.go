@@ -0,0 +1,40 @@
+package ansi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithAltScreen(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	errFull := errors.New("full")
+	err := a.WithAltScreen(func() error {
+		a.Write([]byte("app"))
+		return errFull
+	})
+	if err != errFull {
+		t.Fatalf("expected the error of fn, got %v", err)
+	}
+	if got, want := f.written(), "\x1b[?1049happ\x1b[?1049l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestWithAltScreenPanic(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected the panic to continue")
+		}
+		if got, want := f.written(), "\x1b[?1049h\x1b[?1049l"; got != want {
+			t.Fatalf("got %q, expected %q", got, want)
+		}
+	}()
+	a.WithAltScreen(func() error {
+		panic("oops")
+	})
+}
@@ -0,0 +1,26 @@
+package ansi
+
+import "time"
+
+// positionTimeout bounds the position queries made by
+// Anchor and PushCursor
+const positionTimeout = time.Second
+
+// Anchor records the current cursor position, queried from the
+// terminal, for redrawing a block of output in place. Each call
+// to write starts from the anchor and reset clears from the
+// anchor down. If the terminal does not report its position,
+// writes are made wherever the cursor happens to be.
+func (a *Ansi) Anchor() (write func([]byte), reset func()) {
+	var anchor []byte
+	if r, err := a.query(QueryCursorPosition, isType(Position), positionTimeout); err == nil {
+		anchor = Goto(uint16(r.Pos.Row), uint16(r.Pos.Col))
+	}
+	write = func(p []byte) {
+		a.Write(append(append([]byte{}, anchor...), p...))
+	}
+	reset = func() {
+		a.Write(append(append([]byte{}, anchor...), EraseDown...))
+	}
+	return write, reset
+}
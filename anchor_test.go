@@ -0,0 +1,24 @@
+package ansi
+
+import "testing"
+
+func TestAnchor(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == string(QueryCursorPosition) {
+			return "\x1b[4;2R"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	write, reset := a.Anchor()
+	write([]byte("one"))
+	write([]byte("two"))
+	reset()
+	at := string(Goto(4, 2))
+	want := string(QueryCursorPosition) + at + "one" + at + "two" + at + string(EraseDown)
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
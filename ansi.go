@@ -3,36 +3,166 @@
 package ansi
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // Ansi represents a wrapped io.ReadWriter.
 // It will read the stream, parse and remove ANSI report codes
-// and place them on the Reports queue.
+// and place them on the Reports queue, in the order they
+// were read. The queue holds the latest ReportsBuffer reports,
+// older ones are dropped when nobody is draining it.
 type Ansi struct {
-	rw      io.ReadWriter
-	rerr    error
-	rbuff   chan []byte
+	rw    io.ReadWriter
+	rerr  error
+	rbuff chan Event
+	//data left over by Read
+	rmu     sync.Mutex
+	rleft   []byte
 	Reports chan *Report
+	//what queue does with reports, set by WrapWith
+	//and OnReport
+	overflow Overflow
+	onReport func(*Report)
+	//what scan does with malformed reports
+	malformedPolicy Malformed
+	//Resizes receives the latest size, once it changes,
+	//from PollResizes or Resized
+	Resizes chan Size
+	done    chan struct{}
+	//closed by Close
+	closed    chan struct{}
+	closeOnce sync.Once
+	//reports awaited by queries
+	mu      sync.Mutex
+	waiters []*waiter
+	inline  bool
+	//cursor position queries written and not yet answered
+	positions int
+	//strip the output of Pipe
+	strip bool
+	//the key presses read, once Keys is called
+	keys chan KeyEvent
+	//the mouse events read, once MouseEvents is called
+	mice chan MouseEvent
+	//pasted text being collected
+	collect bool
+	paste   *bytes.Buffer
+	//cached default colors
+	colors []Color
+	//terminal state saved by MakeRaw
+	saved *termState
+	//last known size
+	rows, cols int
+	//positions saved by PushCursor
+	cursors [][2]uint16
+	//the cursor shape to restore once a prompt is answered
+	promptShape *CursorShape
+	//custom report parsers
+	parsers     map[byte]func(params string) *Report
+	terminators []byte
+	reportCode  *regexp.Regexp
+	//the start of a sequence split across reads,
+	//cmu is held while a read is processed
+	cmu   sync.Mutex
+	carry []byte
+	cgen  int
+	//set by EchoReports
+	echo io.Writer
+	//write side, output is held in wbuff when buffering
+	wmu   sync.Mutex
+	w     io.Writer
+	wbuff *bytes.Buffer
+	frame *bytes.Buffer
+	//macros made by Record
+	macros    map[string][]byte
+	recording string
+	record    *bytes.Buffer
+	stop      chan struct{}
+	col       int
+	savedCol  int
+	styled    bool
+	nowrap    bool
+	guards    []*ModeGuard
+	c1        bool
+	//the last error from Write
+	lastWriteErr error
+	//the unfinished sequence held by WriteAtomic
+	partial []byte
+	//the buffer of writeAppend
+	scratch []byte
 }
 
 // Wrap an io.ReadWriter (like a net.Conn) to
 // easily read and write control codes
 func Wrap(rw io.ReadWriter) *Ansi {
+	a := newAnsi(rw, ReportsBuffer)
+	go a.read()
+	return a
+}
+
+// newAnsi returns an Ansi wrapping rw, not yet reading
+func newAnsi(rw io.ReadWriter, reports int) *Ansi {
 	a := &Ansi{}
 	a.rw = rw
-	a.rbuff = make(chan []byte)
-	a.Reports = make(chan *Report)
-	go a.read()
+	a.w = rw
+	a.rbuff = make(chan Event)
+	a.Reports = make(chan *Report, reports)
+	a.Resizes = make(chan Size, 1)
+	a.done = make(chan struct{})
+	a.closed = make(chan struct{})
+	termMu.Lock()
+	a.reportCode = reportCode
+	a.terminators = terminators
+	termMu.Unlock()
 	return a
 }
 
-var reportCode = regexp.MustCompile(`\[([^a-zA-Z]*)(0c|0n|3n|R)`)
+// ReportsBuffer is the capacity of the Reports queue
+const ReportsBuffer = 64
+
+var (
+	termMu      sync.Mutex
+	terminators []byte
+	reportCode  = reportRegexp(nil)
+)
+
+// SetReportTerminators sets extra final bytes which mark report
+// sequences <ESC>[{params}{final}, for example 'u' for the kitty
+// keyboard protocol. These are parsed by RegisterParser handlers
+// when present, otherwise they are reported as Custom reports.
+// Only affects Ansi wrapped afterwards.
+func SetReportTerminators(finals ...byte) {
+	termMu.Lock()
+	defer termMu.Unlock()
+	terminators = append([]byte(nil), finals...)
+	reportCode = reportRegexp(terminators)
+}
+
+// reportRegexp matches the known report codes,
+// along with CSI sequences ending in finals
+func reportRegexp(finals []byte) *regexp.Regexp {
+	codes := `c|n|R|t|\*\{|\$y|I|O`
+	for _, f := range finals {
+		codes += "|" + regexp.QuoteMeta(string(f))
+	}
+	return regexp.MustCompile(`\[([0-9;:?<=>]*)(` + codes + `)|\x1bP([01])\+r([0-9A-Fa-f=;]*)\x1b\\|\x1b\](\d+);([^\x07\x1b]*)(?:\x07|\x1b\\)|\x1bP([01])\$r([^\x1b]*)\x1b\\|\x1b\[<(\d+;\d+;\d+)([Mm])|\x1bP(\d+)!~([0-9A-Fa-f]*)\x1b\\|\x1b\[(\d+;\d+;\d+)M|\x1b\[M([ -\x7f]{3})`)
+}
+
+// Done is closed once reading the underlying ReadWriter
+// stops, at EOF or on an error
+func (a *Ansi) Done() <-chan struct{} {
+	return a.done
+}
 
 // reads the underlying ReadWriter for real,
 // extracts the ansi codes, places the rest
@@ -41,84 +171,486 @@ func (a *Ansi) read() {
 	buff := make([]byte, 0xffff)
 	for {
 		n, err := a.rw.Read(buff)
+		a.cmu.Lock()
+		src := buff[:n]
+		if a.carry != nil {
+			src = append(a.carry, src...)
+			a.carry = nil
+		}
+		a.cgen++
 		if err != nil {
+			//nothing more will complete the carry
+			a.pastes(src)
+			a.cmu.Unlock()
 			a.rerr = err
 			close(a.rbuff)
+			close(a.Reports)
+			a.mu.Lock()
+			if a.mice != nil {
+				close(a.mice)
+			}
+			close(a.done)
+			a.mu.Unlock()
 			break
 		}
+		if i := incomplete(src); i >= 0 {
+			a.carry = append([]byte(nil), src[i:]...)
+			src = src[:i]
+			time.AfterFunc(carryTimeout, a.release(a.cgen))
+		}
+		a.pastes(src)
+		a.cmu.Unlock()
+	}
+}
+
+// carryTimeout is how long the start of a sequence is held
+// back waiting for the rest of it, after which it is passed
+// on as it is (like a lone <ESC> from the escape key)
+const carryTimeout = 50 * time.Millisecond
+
+// maxCarry is the longest sequence start held back
+const maxCarry = 1024
+
+// incomplete returns the index of a sequence which src ends in
+// the middle of, or -1 when every sequence in src is complete.
+// Sequence starts longer than maxCarry are taken as complete.
+func incomplete(src []byte) int {
+	return unfinished(src, maxCarry)
+}
 
-		var src = buff[:n]
-		var dst []byte
+// unfinished is incomplete, with sequence starts longer than max
+// taken as complete, or no limit when max is negative, so a
+// writer can hold a sequence back until it ends, however long.
+func unfinished(src []byte, max int) int {
+	s := string(src)
+	for i := 0; i < len(s); i++ {
+		if s[i] != Esc {
+			continue
+		}
+		n, ok := sequenceLen(s[i:])
+		if !ok {
+			if max >= 0 && len(s)-i > max {
+				return -1
+			}
+			return i
+		}
+		i += n - 1
+	}
+	return -1
+}
 
-		//contain ansi codes?
-		m := reportCode.FindAllStringSubmatchIndex(string(src), -1)
+// release passes on the carry when no read
+// has arrived since generation gen
+func (a *Ansi) release(gen int) func() {
+	return func() {
+		a.cmu.Lock()
+		defer a.cmu.Unlock()
+		if a.cgen != gen || a.carry == nil {
+			return
+		}
+		c := a.carry
+		a.carry = nil
+		a.cgen++
+		a.pastes(c)
+	}
+}
 
-		if len(m) == 0 {
-			dst = make([]byte, n)
-			copy(dst, src)
+// scan extracts the ansi codes from src, returning the
+// reports and the data between them in stream order
+func (a *Ansi) scan(src []byte) []Event {
+	//contain ansi codes?
+	a.mu.Lock()
+	re := a.reportCode
+	a.mu.Unlock()
+	m := re.FindAllStringSubmatchIndex(string(src), -1)
+
+	var events []Event
+	var dst []byte
+	last := 0
+	for _, i := range m {
+		dst = append(dst, src[last:i[0]]...)
+		var r *Report
+		if i[2] >= 0 {
+			//slice off ansi code body and trailing char
+			body, char := string(src[i[2]:i[3]]), string(src[i[4]:i[5]])
+			ok := true
+			if char == "R" && a.modifiedF3(body) {
+				//a key press, not a report
+				dst = append(dst, src[i[0]:i[1]]...)
+			} else if r, ok = a.parse(body, char); !ok {
+				dst, r = a.malformed(dst, src[i[0]:i[1]])
+			}
+		} else if i[6] >= 0 {
+			r = a.parseTermcap(src[i[6]] == '1', string(src[i[8]:i[9]]))
+		} else if i[10] >= 0 {
+			r = a.parseOSC(string(src[i[10]:i[11]]), string(src[i[12]:i[13]]))
+		} else if i[14] >= 0 {
+			r = a.parseSetting(src[i[14]] == '1', string(src[i[16]:i[17]]))
+		} else if i[18] >= 0 {
+			r = a.parseMouse(string(src[i[18]:i[19]]), src[i[20]] == 'M')
+		} else if i[22] >= 0 {
+			r = a.parseChecksum(string(src[i[22]:i[23]]), string(src[i[24]:i[25]]))
+		} else if i[26] >= 0 {
+			r = a.parseURXVTMouse(string(src[i[26]:i[27]]))
 		} else {
-			for _, i := range m {
-				//slice off ansi code body and trailing char
-				a.parse(string(src[i[2]:i[3]]), string(src[i[4]:i[5]]))
-				//add surrounding bits to dst buffer
-				dst = append(dst, src[:i[0]]...)
-				dst = append(dst, src[i[1]:]...)
+			r = a.parseX10Mouse(src[i[28]:i[29]])
+		}
+		if r != nil {
+			if len(dst) > 0 {
+				events = append(events, DataEvent{Bytes: dst})
+				dst = nil
 			}
-			if len(dst) == 0 {
-				continue
+			events = append(events, ReportEvent{Report: r})
+		}
+		last = i[1]
+	}
+	if dst = append(dst, src[last:]...); len(dst) > 0 {
+		events = append(events, DataEvent{Bytes: dst})
+	}
+	return events
+}
+
+// modifiedF3 reports whether the body of an <ESC>[{body}R
+// is F3 with modifiers, <ESC>[1;{modifiers}R, rather than
+// a cursor position. They look the same, so it is only a
+// position while a query for one is waiting to be answered.
+func (a *Ansi) modifiedF3(body string) bool {
+	p := params(body)
+	if strings.HasPrefix(body, "?") || len(p) != 2 || p[0] != 1 || p[1] < 2 || p[1] > 16 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.positions == 0
+}
+
+// forward passes on what scan found. The data is passed on
+// after the reports, so a reader waiting on Reports is not
+// blocked by data nobody is reading. ReadEvent needs stream
+// order, so there everything is passed on as it was found.
+func (a *Ansi) forward(events []Event) {
+	a.mu.Lock()
+	inline := a.inline
+	a.mu.Unlock()
+	var data []byte
+	for _, e := range events {
+		switch e := e.(type) {
+		case ReportEvent:
+			a.report(e.Report)
+		case DataEvent:
+			if inline {
+				a.event(e)
+			} else {
+				data = append(data, e.Bytes...)
 			}
 		}
+	}
+	if len(data) > 0 {
+		a.event(DataEvent{Bytes: data})
+	}
+}
 
-		a.rbuff <- dst
+// event places e in the read buffer,
+// it is dropped once a is closed
+func (a *Ansi) event(e Event) {
+	select {
+	case a.rbuff <- e:
+	case <-a.closed:
+	}
+}
+
+// next takes the next event from the read buffer
+func (a *Ansi) next() (Event, error) {
+	select {
+	case e, open := <-a.rbuff:
+		if !open {
+			return nil, a.rerr
+		}
+		return e, nil
+	case <-a.closed:
+		return nil, ErrClosed
 	}
 }
 
 // Report Device Code	<ESC>[{code}0c
+// Report Device Attributes	<ESC>[?{attr1};...;{attrn}c
 // Report Device OK	<ESC>[0n
 // Report Device Failure	<ESC>[3n
+// Report Printer Status	<ESC>[?{status}n
 // Report Cursor Position	<ESC>[{ROW};{COLUMN}R
-func (a *Ansi) parse(body, char string) {
+// Report Extended Position	<ESC>[?{ROW};{COLUMN};{PAGE}R
+// Report Window		<ESC>[{n};...t
+// Report Macro Space	<ESC>[{n}*{
+// Report Secondary Attributes	<ESC>[>{type};{version};{rom}c
+// Report Mode		<ESC>[{?}{mode};{status}$y
+// Report Focus In		<ESC>[I
+// Report Focus Out		<ESC>[O
+func (a *Ansi) parse(body, char string) (*Report, bool) {
 	r := &Report{}
 	switch char {
-	case "0c":
+	case "c":
 		r.Type = Code
-		r.Code, _ = strconv.Atoi(body)
-	case "0n":
-		r.Type = OK
-	case "3n":
-		r.Type = Failure
+		if strings.HasPrefix(body, ">") {
+			r.Type = SecondaryCode
+		}
+		r.Text = body
+		r.Params = params(body)
+		if strings.Trim(body, "0123456789") == "" {
+			//the legacy form, the code precedes a 0
+			r.Code, _ = strconv.Atoi(strings.TrimSuffix(body, "0"))
+		} else {
+			r.Code = r.Params[0]
+		}
+	case "n":
+		switch body {
+		case "0":
+			r.Type = OK
+		case "3":
+			r.Type = Failure
+		case "?10", "?11", "?13", "?18", "?19":
+			r.Type = PrinterStatus
+			r.Code, _ = strconv.Atoi(body[1:])
+		default:
+			return nil, false
+		}
 	case "R":
 		r.Type = Position
-		pair := strings.Split(body, ";")
-		r.Pos.Col, _ = strconv.Atoi(pair[1])
-		r.Pos.Row, _ = strconv.Atoi(pair[0])
+		//row and column are the first two fields, then
+		//the page in the extended form <ESC>[?{ROW};{COLUMN};{PAGE}R,
+		//reports missing a field are dropped
+		p := params(body)
+		if len(p) < 2 || p[0] < 1 || p[1] < 1 {
+			return nil, false
+		}
+		r.Pos.Row, r.Pos.Col = p[0], p[1]
+		if len(p) > 2 {
+			r.Pos.Page = p[2]
+		}
+		a.mu.Lock()
+		if a.positions > 0 {
+			a.positions--
+		}
+		a.mu.Unlock()
+	case "t":
+		r.Type = Window
+		r.Params = params(body)
+	case "$y":
+		//the mode and its status
+		r.Type = ModeStatus
+		r.Text = body
+		if r.Params = params(body); len(r.Params) != 2 {
+			return nil, false
+		}
+		r.Code = r.Params[0]
+	case "I", "O":
+		if body != "" {
+			return nil, false
+		}
+		r.Type = Focus
+		if char == "O" {
+			r.Type = Blur
+		}
+	case "*{":
+		r.Type = MacroSpace
+		var err error
+		if r.Code, err = strconv.Atoi(body); err != nil {
+			return nil, false
+		}
 	default:
-		return
+		a.mu.Lock()
+		fn := a.parsers[char[0]]
+		a.mu.Unlock()
+		if fn == nil {
+			r.Type = Custom
+			r.Final = char[0]
+			r.Params = params(body)
+		} else if r = fn(body); r == nil {
+			//the parser chose to drop it
+			return nil, true
+		}
 	}
 	// fmt.Printf("parsed report: %+v", r)
-	a.Reports <- r
+	return r, true
+}
+
+// params splits a report body into its numeric fields,
+// private markers (like "?") are dropped and invalid
+// fields are zero
+func params(body string) []int {
+	fields := strings.Split(strings.TrimLeft(body, "?<=>"), ";")
+	p := make([]int, len(fields))
+	for i, f := range fields {
+		p[i], _ = strconv.Atoi(f)
+	}
+	return p
 }
 
 // Reads the underlying ReadWriter
 func (a *Ansi) Read(dest []byte) (n int, err error) {
 	//It doesn't really read the underlying ReadWriter :)
-	if a.rerr != nil {
-		return 0, a.rerr
+	a.rmu.Lock()
+	if len(a.rleft) > 0 {
+		n = copy(dest, a.rleft)
+		a.rleft = a.rleft[n:]
+		a.rmu.Unlock()
+		return n, nil
+	}
+	a.rmu.Unlock()
+	for {
+		e, err := a.next()
+		if err != nil {
+			return 0, err
+		}
+		//inline reports are only seen by ReadEvent
+		if d, ok := e.(DataEvent); ok {
+			a.answered(d.Bytes)
+			n = copy(dest, d.Bytes)
+			if n < len(d.Bytes) {
+				//what does not fit is kept for the next read
+				a.rmu.Lock()
+				a.rleft = append(a.rleft, d.Bytes[n:]...)
+				a.rmu.Unlock()
+			}
+			return n, nil
+		}
+	}
+}
+
+// leftover takes the data a Read had no room for
+func (a *Ansi) leftover() []byte {
+	a.rmu.Lock()
+	defer a.rmu.Unlock()
+	b := a.rleft
+	a.rleft = nil
+	return b
+}
+
+// RegisterParser handles report sequences <ESC>[{params}{final}
+// which the package does not know about. fn may return nil
+// to discard the sequence, custom reports should generally
+// use the Custom type.
+func (a *Ansi) RegisterParser(final byte, fn func(params string) *Report) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.parsers == nil {
+		a.parsers = map[byte]func(string) *Report{}
 	}
-	src, open := <-a.rbuff
-	if !open {
-		return 0, a.rerr
+	a.parsers[final] = fn
+	finals := append([]byte(nil), a.terminators...)
+	for f := range a.parsers {
+		finals = append(finals, f)
 	}
-	return copy(dest, src), nil
+	a.reportCode = reportRegexp(finals)
 }
 
 // Writes the underlying ReadWriter
 func (a *Ansi) Write(p []byte) (n int, err error) {
-	return a.rw.Write(p)
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	return a.writeLocked(p)
+}
+
+// writeLocked is Write, with wmu held
+func (a *Ansi) writeLocked(p []byte) (n int, err error) {
+	select {
+	case <-a.closed:
+		a.lastWriteErr = ErrClosed
+		return 0, ErrClosed
+	default:
+	}
+	n, err = a.output(p)
+	if err != nil {
+		a.lastWriteErr = err
+	}
+	return n, err
+}
+
+// writeAppend writes what build appends, building it in a
+// buffer kept between calls, so the helpers which write
+// sequences do not allocate. Nothing is written when build
+// appends nothing.
+func (a *Ansi) writeAppend(build func(dst []byte) []byte) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	select {
+	case <-a.closed:
+		a.lastWriteErr = ErrClosed
+		return
+	default:
+	}
+	if a.scratch = build(a.scratch[:0]); len(a.scratch) == 0 {
+		return
+	}
+	if _, err := a.output(a.scratch); err != nil {
+		a.lastWriteErr = err
+	}
 }
 
-// Close the underlying ReadWriter
+// WriteErr returns the last error from writing. The helpers which
+// write sequences drop theirs, so after many calls it can be
+// checked once instead.
+func (a *Ansi) WriteErr() error {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	return a.lastWriteErr
+}
+
+// output tracks and writes p, wmu must be held
+func (a *Ansi) output(p []byte) (int, error) {
+	a.track(p)
+	if q := bytes.Count(p, QueryCursorPosition); q > 0 {
+		a.mu.Lock()
+		a.positions += q
+		a.mu.Unlock()
+	}
+	if a.record != nil {
+		a.record.Write(p)
+	}
+	if a.c1 {
+		if _, err := a.write(to8Bit(p)); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return a.write(p)
+}
+
+// write p to the frame or buffer when buffering,
+// otherwise to the underlying writer
+func (a *Ansi) write(p []byte) (int, error) {
+	if a.frame != nil {
+		return a.frame.Write(p)
+	}
+	if a.wbuff != nil {
+		return a.wbuff.Write(p)
+	}
+	return a.w.Write(p)
+}
+
+// ErrClosed is returned when using an Ansi after Close
+var ErrClosed = errors.New("Ansi is closed")
+
+// Close the underlying ReadWriter, which stops the reader once its
+// read returns. Reads and writes after closing return ErrClosed,
+// as does closing again.
 func (a *Ansi) Close() error {
+	err := ErrClosed
+	a.closeOnce.Do(func() {
+		a.wmu.Lock()
+		a.writePartial()
+		a.flush()
+		if a.stop != nil {
+			close(a.stop)
+			a.stop = nil
+		}
+		close(a.closed)
+		a.wmu.Unlock()
+		err = a.closeRW()
+	})
+	return err
+}
+
+func (a *Ansi) closeRW() error {
 	c, ok := a.rw.(io.Closer)
 	if !ok {
 		return errors.New("Provided ReadWriter is not a Closer")
@@ -135,14 +667,56 @@ const (
 	OK
 	Failure
 	Position
+	Termcap
+	Window
+	Custom
+	Paste
+	OSC
+	PrinterStatus
+	Setting
+	Mouse
+	MacroSpace
+	Checksum
+	SecondaryCode
+	ModeStatus
+	Focus
+	Blur
+	Invalid
 )
 
 type Report struct {
 	Type ReportType
+	//Code holds the device code, the number
+	//of an OSC report, the printer status, the
+	//button of a Mouse report, the free macro
+	//space, the id of a Checksum report, the
+	//terminal type of a SecondaryCode report
+	//or the mode of a ModeStatus report
 	Code int
-	Pos  struct {
-		Row, Col int
+	//Pos holds a Position report, Page is
+	//only set by the extended (DECXCPR) form
+	Pos struct {
+		Row, Col, Page int
 	}
+	//Caps holds the capabilities of a Termcap
+	//report, it is nil when the terminal rejected
+	//the query
+	Caps map[string]string
+	//Params holds the fields of Code, SecondaryCode,
+	//Window and Custom reports, or the mode and its
+	//status (see ModeSet) for ModeStatus reports
+	Params []int
+	//Final holds the final byte of a Custom report
+	Final byte
+	//Press is true when a Mouse report is a button
+	//press (or motion) rather than a release
+	Press bool
+	//Text holds the content of a Paste or OSC report,
+	//the raw parameters of a Code report, the hex
+	//digits of a Checksum report,
+	//or the value of a Setting report (empty when the
+	//terminal rejected the query)
+	Text string
 }
 
 //==============================
@@ -150,22 +724,91 @@ type Report struct {
 const Esc = byte(27)
 
 var QueryCode = []byte{Esc, '[', 'c'}
+
+// Query Secondary Attributes	<ESC>[>c
+var QuerySecondaryCode = []byte{Esc, '[', '>', 'c'}
 var QueryDeviceStatus = []byte{Esc, '[', '5', 'n'}
 var QueryCursorPosition = []byte{Esc, '[', '6', 'n'}
 
+// Query Printer Status	<ESC>[?15n
+// Report Printer Ready	<ESC>[?10n
+// Report No Printer	<ESC>[?13n
+var QueryPrinterStatus = []byte{Esc, '[', '?', '1', '5', 'n'}
+
+// Printer statuses, the Code of a PrinterStatus report
+const (
+	PrinterReady    = 10
+	PrinterNotReady = 11
+	NoPrinter       = 13
+	PrinterBusy     = 18
+	PrinterAssigned = 19
+)
+
+// Mode statuses, the second of the Params of a ModeStatus report
+const (
+	ModeUnknown = iota
+	ModeSet
+	ModeReset
+	ModeAlwaysSet
+	ModeAlwaysReset
+)
+
+func (a *Ansi) QueryPrinterStatus() {
+	a.Write(QueryPrinterStatus)
+}
+
 func (a *Ansi) QueryCursorPosition() {
 	a.Write(QueryCursorPosition)
 }
 
+func (a *Ansi) QueryDeviceCode() {
+	a.Write(QueryCode)
+}
+
+func (a *Ansi) QuerySecondaryCode() {
+	a.Write(QuerySecondaryCode)
+}
+
+func (a *Ansi) QueryDeviceStatus() {
+	a.Write(QueryDeviceStatus)
+}
+
+// GetCursorPosition asks the terminal where the cursor is and waits
+// for the answer. Only the Position report is taken, other reports
+// still arrive on Reports. ErrTimeout is returned if nothing is heard.
+func (a *Ansi) GetCursorPosition(timeout time.Duration) (row, col int, err error) {
+	r, err := a.query(QueryCursorPosition, isType(Position), timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	return r.Pos.Row, r.Pos.Col, nil
+}
+
+// CursorPosition is GetCursorPosition, waiting until ctx is done
+// rather than for a timeout, in which case ctx.Err() is returned
+func (a *Ansi) CursorPosition(ctx context.Context) (row, col int, err error) {
+	r, err := a.queryContext(ctx, QueryCursorPosition, isType(Position))
+	if err != nil {
+		return 0, 0, err
+	}
+	return r.Pos.Row, r.Pos.Col, nil
+}
+
 var ResetDevice = []byte{Esc, 'c'}
 
-var EnableLineWrap = []byte{Esc, '[', '7', 'h'}
+func (a *Ansi) ResetDevice() {
+	a.Write(ResetDevice)
+}
+
+// Enable Line Wrap	<ESC>[?7h
+// Disable Line Wrap	<ESC>[?7l
+var EnableLineWrap = []byte{Esc, '[', '?', '7', 'h'}
 
 func (a *Ansi) EnableLineWrap() {
-	a.Write(DisableLineWrap)
+	a.Write(EnableLineWrap)
 }
 
-var DisableLineWrap = []byte{Esc, '[', '7', 'l'}
+var DisableLineWrap = []byte{Esc, '[', '?', '7', 'l'}
 
 func (a *Ansi) DisableLineWrap() {
 	a.Write(DisableLineWrap)
@@ -182,23 +825,66 @@ var FontSetG1 = []byte{Esc, ')'}
 // Cursor Backward		<ESC>[{COUNT}D
 // Force Cursor Position	<ESC>[{ROW};{COLUMN}f
 func Goto(r, c uint16) []byte {
-	rb := []byte(strconv.Itoa(int(r)))
-	cb := []byte(strconv.Itoa(int(c)))
-	b := append([]byte{Esc, '['}, rb...)
-	b = append(b, ';')
-	b = append(b, cb...)
-	b = append(b, 'f')
-	return b
+	return AppendGoto(nil, r, c)
+}
+
+// AppendGoto appends the Goto sequence to dst
+func AppendGoto(dst []byte, r, c uint16) []byte {
+	return appendPosition(dst, r, c, 'H')
 }
 
 func (a *Ansi) Goto(r, c uint16) {
-	a.Write(Goto(r, c))
+	a.writeAppend(func(dst []byte) []byte { return AppendGoto(dst, r, c) })
+}
+
+// Force is Goto using the Force Cursor Position form
+func Force(r, c uint16) []byte {
+	return appendPosition(nil, r, c, 'f')
+}
+
+func (a *Ansi) Force(r, c uint16) {
+	a.Write(Force(r, c))
+}
+
+func appendPosition(dst []byte, r, c uint16, final byte) []byte {
+	dst = append(dst, Esc, '[')
+	dst = strconv.AppendUint(dst, uint64(r), 10)
+	dst = append(dst, ';')
+	dst = strconv.AppendUint(dst, uint64(c), 10)
+	return append(dst, final)
 }
 
+// Save Cursor			<ESC>[s
+// Unsave Cursor		<ESC>[u
+// Save Cursor & Attrs		<ESC>7
+// Restore Cursor & Attrs	<ESC>8
 var SaveCursor = []byte{Esc, '[', 's'}
 var UnsaveCursor = []byte{Esc, '[', 'u'}
 var SaveAttrCursor = []byte{Esc, '7'}
 var RestoreAttrCursor = []byte{Esc, '8'}
+
+// RestoreCursor, SaveCursorAttrs and RestoreCursorAttrs
+// are UnsaveCursor, SaveAttrCursor and RestoreAttrCursor
+var RestoreCursor = UnsaveCursor
+var SaveCursorAttrs = SaveAttrCursor
+var RestoreCursorAttrs = RestoreAttrCursor
+
+func (a *Ansi) SaveCursor() {
+	a.Write(SaveCursor)
+}
+
+func (a *Ansi) RestoreCursor() {
+	a.Write(RestoreCursor)
+}
+
+func (a *Ansi) SaveCursorAttrs() {
+	a.Write(SaveCursorAttrs)
+}
+
+func (a *Ansi) RestoreCursorAttrs() {
+	a.Write(RestoreCursorAttrs)
+}
+
 var CursorHide = []byte{Esc, '[', '?', '2', '5', 'l'}
 
 func (a *Ansi) CursorHide() {
@@ -211,6 +897,19 @@ func (a *Ansi) CursorShow() {
 	a.Write(CursorShow)
 }
 
+// GuardTerminal returns a func for defer, which resets the display
+// attributes and shows the cursor. Deferred funcs also run while
+// panicking, so the terminal is left usable either way.
+func (a *Ansi) GuardTerminal() func() {
+	return func() {
+		a.Write(append(Set(Reset), CursorShow...))
+	}
+}
+
+// Scroll Screen		<ESC>[r
+// Scroll Region		<ESC>[{start};{end}r
+// Scroll Down		<ESC>D
+// Scroll Up		<ESC>M
 var ScrollScreen = []byte{Esc, '[', 'r'}
 var ScrollDown = []byte{Esc, 'D'}
 var ScrollUp = []byte{Esc, 'M'}
@@ -219,10 +918,114 @@ func Scroll(start, end uint16) []byte {
 	return []byte(string(Esc) + fmt.Sprintf("[%d;%dr", start, end))
 }
 
+// ScrollRegion is Scroll, though a region which is empty
+// (start >= end) resets scrolling to the whole screen
+func ScrollRegion(start, end uint16) []byte {
+	if start >= end {
+		return ScrollScreen
+	}
+	return Scroll(start, end)
+}
+
+func (a *Ansi) ScrollRegion(start, end uint16) {
+	a.Write(ScrollRegion(start, end))
+}
+
+func (a *Ansi) ScrollScreen() {
+	a.Write(ScrollScreen)
+}
+
+func (a *Ansi) ScrollDown() {
+	a.Write(ScrollDown)
+}
+
+func (a *Ansi) ScrollUp() {
+	a.Write(ScrollUp)
+}
+
+// SetScrollRegion is ScrollRegion and
+// ResetScrollRegion is ScrollScreen
+var ResetScrollRegion = ScrollScreen
+
+func SetScrollRegion(start, end uint16) []byte {
+	return ScrollRegion(start, end)
+}
+
+func (a *Ansi) SetScrollRegion(start, end uint16) {
+	a.Write(SetScrollRegion(start, end))
+}
+
+func (a *Ansi) ResetScrollRegion() {
+	a.Write(ResetScrollRegion)
+}
+
+// Scroll Lines Up	<ESC>[{n}S
+// Scroll Lines Down	<ESC>[{n}T
+// These scroll the content of the scroll region by n lines,
+// up or down, without moving the cursor. ScrollUp and
+// ScrollDown scroll only when the cursor is at the edge.
+// Terminals take a count of 0 as 1, so for 0 the builders
+// return nothing and the methods write nothing.
+func ScrollLinesUp(n uint16) []byte {
+	return appendScroll(nil, n, 'S')
+}
+
+func ScrollLinesDown(n uint16) []byte {
+	return appendScroll(nil, n, 'T')
+}
+
+func (a *Ansi) ScrollLinesUp(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendScroll(dst, n, 'S') })
+}
+
+func (a *Ansi) ScrollLinesDown(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendScroll(dst, n, 'T') })
+}
+
+// appendScroll appends <ESC>[{n}{final}, nothing for 0
+func appendScroll(dst []byte, n uint16, final byte) []byte {
+	if n == 0 {
+		return dst
+	}
+	dst = append(dst, Esc, '[')
+	dst = strconv.AppendInt(dst, int64(n), 10)
+	return append(dst, final)
+}
+
+// Index			<ESC>D
+// Reverse Index	<ESC>M
+// Next Line		<ESC>E
+// Index moves the cursor down a line and reverse index moves it up,
+// both scroll when already at the edge of the scroll region. Next
+// line also returns the cursor to the start of the line. Index and
+// reverse index are the same sequences as ScrollDown and ScrollUp.
+var Index = ScrollDown
+var ReverseIndex = ScrollUp
+var NextLine = []byte{Esc, 'E'}
+
+func (a *Ansi) Index() {
+	a.Write(Index)
+}
+
+func (a *Ansi) ReverseIndex() {
+	a.Write(ReverseIndex)
+}
+
+func (a *Ansi) NextLine() {
+	a.Write(NextLine)
+}
+
 // Tab Control
 var SetTab = []byte{Esc, 'H'}
 var ClearTab = []byte{Esc, '[', 'g'}
 var ClearAllTabs = []byte{Esc, '[', '3', 'g'}
+
+// Erase End of Line	<ESC>[K
+// Erase Start of Line	<ESC>[1K
+// Erase Line		<ESC>[2K
+// Erase Down		<ESC>[J
+// Erase Up		<ESC>[1J
+// Erase Screen		<ESC>[2J
 var EraseEndLine = []byte{Esc, '[', 'K'}
 var EraseStartLine = []byte{Esc, '[', '1', 'K'}
 var EraseLine = []byte{Esc, '[', '2', 'K'}
@@ -230,10 +1033,48 @@ var EraseDown = []byte{Esc, '[', 'J'}
 var EraseUp = []byte{Esc, '[', '1', 'J'}
 var EraseScreen = []byte{Esc, '[', '2', 'J'}
 
+// EraseEndOfLine and EraseStartOfLine are
+// EraseEndLine and EraseStartLine
+var EraseEndOfLine = EraseEndLine
+var EraseStartOfLine = EraseStartLine
+
+func (a *Ansi) EraseEndOfLine() {
+	a.Write(EraseEndOfLine)
+}
+
+func (a *Ansi) EraseStartOfLine() {
+	a.Write(EraseStartOfLine)
+}
+
+func (a *Ansi) EraseLine() {
+	a.Write(EraseLine)
+}
+
+func (a *Ansi) EraseDown() {
+	a.Write(EraseDown)
+}
+
+func (a *Ansi) EraseUp() {
+	a.Write(EraseUp)
+}
+
 func (a *Ansi) EraseScreen() {
 	a.Write(EraseScreen)
 }
 
+// ClearToEnd resets the display attributes and then erases from the
+// cursor to the end of the screen. The reset comes first since the
+// erase fills cells with the current background color.
+func (a *Ansi) ClearToEnd() {
+	a.Write(append(Set(Reset), EraseDown...))
+}
+
+// WriteClearEOL writes s and erases whatever was left on
+// the line after it, for overwriting longer content
+func (a *Ansi) WriteClearEOL(s string) {
+	a.Write(append([]byte(s), EraseEndLine...))
+}
+
 // Printing
 var PrintScreen = []byte{Esc, '[', 'i'}
 var PrintLine = []byte{Esc, '[', '1', 'i'}
@@ -241,6 +1082,31 @@ var StopPrintLog = []byte{Esc, '[', '4', 'i'}
 var StartPrintLog = []byte{Esc, '[', '5', 'i'}
 
 // Set Key Definition	<ESC>[{key};"{ascii}"p
+func SetKeyDefinition(key int, ascii string) []byte {
+	b := append([]byte{Esc, '['}, strconv.Itoa(key)...)
+	b = append(b, ';', '"')
+	b = append(b, ascii...)
+	return append(b, '"', 'p')
+}
+
+func (a *Ansi) SetKeyDefinition(key int, ascii string) {
+	a.Write(SetKeyDefinition(key, ascii))
+}
+
+// SetKeyDefinitions writes a definition for each key, in key
+// order, as a single write
+func (a *Ansi) SetKeyDefinitions(defs map[int]string) {
+	keys := make([]int, 0, len(defs))
+	for k := range defs {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	var b []byte
+	for _, k := range keys {
+		b = append(b, SetKeyDefinition(k, defs[k])...)
+	}
+	a.Write(b)
+}
 
 // Sets multiple display attribute settings. The following lists standard attributes:
 type Attribute string
@@ -279,6 +1145,65 @@ const (
 	DefaultBG Attribute = "49"
 )
 
+// FG256 and BG256 select a color from the 256 color palette
+// Set 256 Color		<ESC>[38;5;{n}m
+// Set 256 Background	<ESC>[48;5;{n}m
+func FG256(n uint8) Attribute {
+	return Attribute("38;5;" + strconv.Itoa(int(n)))
+}
+
+func BG256(n uint8) Attribute {
+	return Attribute("48;5;" + strconv.Itoa(int(n)))
+}
+
+// FGRGB and BGRGB select a 24-bit color
+// Set RGB Color		<ESC>[38;2;{r};{g};{b}m
+// Set RGB Background	<ESC>[48;2;{r};{g};{b}m
+func FGRGB(r, g, b uint8) Attribute {
+	return rgb("38", r, g, b)
+}
+
+func BGRGB(r, g, b uint8) Attribute {
+	return rgb("48", r, g, b)
+}
+
+// Color256, BGColor256 and RGB are FG256,
+// BG256 and FGRGB
+func Color256(n uint8) Attribute {
+	return FG256(n)
+}
+
+func BGColor256(n uint8) Attribute {
+	return BG256(n)
+}
+
+func RGB(r, g, b uint8) Attribute {
+	return FGRGB(r, g, b)
+}
+
+func rgb(slot string, r, g, b uint8) Attribute {
+	return Attribute(slot + ";2;" + strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b)))
+}
+
+// WrapString returns s styled with attrs and followed by a reset,
+// or s unchanged when there are no attrs. The reset is left out
+// when s already ends in one.
+func WrapString(s string, attrs ...Attribute) string {
+	if len(attrs) == 0 {
+		return s
+	}
+	reset := string(Set(Reset))
+	if strings.HasSuffix(s, reset) {
+		reset = ""
+	}
+	return string(Set(attrs...)) + s + reset
+}
+
+// Colorize is WrapString
+func Colorize(s string, attrs ...Attribute) string {
+	return WrapString(s, attrs...)
+}
+
 // String joins: attribute, string, reset, and converts to a string
 func (a Attribute) String(s string) string {
 	return string(a.Join(s, Reset))
@@ -289,20 +1214,72 @@ func (a Attribute) Join(s string, b Attribute) []byte {
 	return append(Set(a), append([]byte(s), Set(b)...)...)
 }
 
+// Dim combines the attribute with Dim, for a
+// de-emphasized variant of a color
+func (a Attribute) Dim() Attribute {
+	return Dim + ";" + a
+}
+
+// IsBackground is true for background colors: the basic (40-47),
+// default (49), bright (100-107) and extended (48;...) forms
+func (a Attribute) IsBackground() bool {
+	return sgrSlot(string(a)) == "bg"
+}
+
 // Set attributes
 func Set(attrs ...Attribute) []byte {
-	s := make([]string, len(attrs))
+	return appendSet(nil, attrs...)
+}
+
+// appendSet is AppendSet with the colors converted to ColorProfile
+func appendSet(dst []byte, attrs ...Attribute) []byte {
+	if ColorProfile < TrueColor && len(attrs) > 0 {
+		//a sequence left empty would be a reset
+		if attrs = ColorProfile.downsample(attrs); len(attrs) == 0 {
+			return dst
+		}
+	}
+	return AppendSet(dst, attrs...)
+}
+
+// AppendSet appends the Set sequence to dst
+func AppendSet(dst []byte, attrs ...Attribute) []byte {
+	dst = append(dst, Esc, '[')
 	for i, a := range attrs {
-		s[i] = string(a)
+		if i > 0 {
+			dst = append(dst, ';')
+		}
+		dst = append(dst, a...)
 	}
-	b := []byte(strings.Join(s, ";"))
-	b = append(b, 'm')
-	return append([]byte{Esc, '['}, b...)
+	return append(dst, 'm')
 }
 
 // Set Attribute Mode	<ESC>[{attr1};...;{attrn}m
 func (a *Ansi) Set(attrs ...Attribute) {
-	a.Write(Set(attrs...))
+	a.writeAppend(func(dst []byte) []byte { return appendSet(dst, attrs...) })
+}
+
+// WriteAutoReset writes p followed by a reset,
+// but only if p leaves a style active
+func (a *Ansi) WriteAutoReset(p []byte) (int, error) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	n, err := a.output(p)
+	if err != nil || !a.styled {
+		return n, err
+	}
+	_, err = a.output(Set(Reset))
+	return n, err
+}
+
+// StylePrefix returns the sequence setting attrs and the reset which
+// undoes it, for wrapping content that is written in pieces. Both
+// are empty when no attributes are given.
+func (a *Ansi) StylePrefix(attrs ...Attribute) (prefix, suffix []byte) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	return Set(attrs...), Set(Reset)
 }
 
 var (
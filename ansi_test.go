@@ -1,7 +1,845 @@
 package ansi
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
 
 func TestBasic(t *testing.T) {
 	t.Log(Green.String("foo"))
 }
+
+// fakeTerm is an in-memory terminal, input is fed
+// with send() and all output is captured
+type fakeTerm struct {
+	r   *io.PipeReader
+	w   *io.PipeWriter
+	mu  sync.Mutex
+	out bytes.Buffer
+	//reply, when set, answers each write
+	reply   func(p string) string
+	replies chan string
+}
+
+func newFakeTerm() *fakeTerm {
+	f := &fakeTerm{}
+	f.r, f.w = io.Pipe()
+	f.replies = make(chan string, 64)
+	go func() {
+		for s := range f.replies {
+			f.send(s)
+		}
+	}()
+	return f
+}
+
+func (f *fakeTerm) Read(p []byte) (int, error) {
+	return f.r.Read(p)
+}
+
+func (f *fakeTerm) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.reply != nil {
+		if s := f.reply(string(p)); s != "" {
+			f.replies <- s
+		}
+	}
+	return f.out.Write(p)
+}
+
+func (f *fakeTerm) Close() error {
+	f.w.Close()
+	return f.r.Close()
+}
+
+// send feeds s to the reader
+func (f *fakeTerm) send(s string) {
+	f.w.Write([]byte(s))
+}
+
+// written returns all output so far
+func (f *fakeTerm) written() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.out.String()
+}
+
+// writeLog records each write separately, there is nothing
+// to read so the reader of a wrapped writeLog stops at once
+type writeLog [][]byte
+
+func (w *writeLog) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (w *writeLog) Write(p []byte) (int, error) {
+	*w = append(*w, append([]byte(nil), p...))
+	return len(p), nil
+}
+
+// nopWriter is writeLog without the log, writes are
+// dropped, or fail with err when it is set
+type nopWriter struct {
+	err error
+}
+
+func (nopWriter) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (w nopWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return len(p), nil
+}
+
+// defaultTicker restores newTicker after a test fakes it
+var defaultTicker = newTicker
+
+func TestClearToEnd(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.ClearToEnd()
+	if got := f.written(); got != "\x1b[0m\x1b[J" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestPositionReportExtraFields(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("\x1b[?5;7;2R\x1b[3;4R")
+	r := <-a.Reports
+	if r.Type != Position || r.Pos.Row != 5 || r.Pos.Col != 7 || r.Pos.Page != 2 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	r = <-a.Reports
+	if r.Type != Position || r.Pos.Row != 3 || r.Pos.Col != 4 || r.Pos.Page != 0 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestPositionReportOneField(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	//short reports are dropped, the next one still arrives
+	go f.send("\x1b[5R\x1b[R\x1b[2;3R")
+	r := <-a.Reports
+	if r.Type != Position || r.Pos.Row != 2 || r.Pos.Col != 3 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	if got, want := AppendSet([]byte("x"), Green, BlueBG), append([]byte("x"), Set(Green, BlueBG)...); !bytes.Equal(got, want) {
+		t.Errorf("AppendSet = %q, expected %q", got, want)
+	}
+	if got, want := AppendGoto(nil, 12, 345), Goto(12, 345); !bytes.Equal(got, want) {
+		t.Errorf("AppendGoto = %q, expected %q", got, want)
+	}
+}
+
+func BenchmarkSet(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Set(Bright, Green, BlueBG)
+	}
+}
+
+func BenchmarkAppendSet(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendSet(buf[:0], Bright, Green, BlueBG)
+	}
+}
+
+func BenchmarkGoto(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Goto(24, 80)
+	}
+}
+
+func BenchmarkAppendGoto(b *testing.B) {
+	b.ReportAllocs()
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf = AppendGoto(buf[:0], 24, 80)
+	}
+}
+
+func BenchmarkAnsiSet(b *testing.B) {
+	a := Wrap(nopWriter{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.Set(Bright, Green, BlueBG)
+	}
+}
+
+func BenchmarkAnsiGoto(b *testing.B) {
+	a := Wrap(nopWriter{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.Goto(24, 80)
+	}
+}
+
+func BenchmarkAnsiUp(b *testing.B) {
+	a := Wrap(nopWriter{})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.Up(3)
+	}
+}
+
+func TestRegisterParser(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.RegisterParser('u', func(body string) *Report {
+		return &Report{Type: Custom, Params: params(body)}
+	})
+	go f.send("\x1b[?5u")
+	r := <-a.Reports
+	if r.Type != Custom || len(r.Params) != 1 || r.Params[0] != 5 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestStylePrefix(t *testing.T) {
+	a := &Ansi{}
+	prefix, suffix := a.StylePrefix(Bright, Yellow, BlueBG)
+	if string(prefix) != "\x1b[1;33;44m" || string(suffix) != "\x1b[0m" {
+		t.Fatalf("got %q %q", prefix, suffix)
+	}
+	if prefix, suffix := a.StylePrefix(); prefix != nil || suffix != nil {
+		t.Fatalf("expected no style, got %q %q", prefix, suffix)
+	}
+}
+
+func TestGuardTerminal(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	func() {
+		defer func() { recover() }()
+		defer a.GuardTerminal()()
+		a.CursorHide()
+		panic("boom")
+	}()
+	if got := f.written(); got != "\x1b[?25l\x1b[0m\x1b[?25h" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestRepeatedPositionReports(t *testing.T) {
+	f := newFakeTerm()
+	n := 0
+	f.reply = func(q string) string {
+		n++
+		return "\x1b[" + strconv.Itoa(n) + ";" + strconv.Itoa(n*10) + "R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	for i := 0; i < 3; i++ {
+		a.QueryCursorPosition()
+	}
+	for i := 1; i <= 3; i++ {
+		r := <-a.Reports
+		if r.Type != Position || r.Pos.Row != i || r.Pos.Col != i*10 {
+			t.Fatalf("report %d out of order: %+v", i, r)
+		}
+	}
+}
+
+func TestSetKeyDefinitions(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	a.SetKeyDefinitions(map[int]string{
+		65: "b",
+		0:  "dir",
+		27: "q",
+	})
+	if len(w) != 1 {
+		t.Fatalf("expected a single write, got %q", w)
+	}
+	if got := string(w[0]); got != "\x1b[0;\"dir\"p\x1b[27;\"q\"p\x1b[65;\"b\"p" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestIsBackground(t *testing.T) {
+	for a, bg := range map[Attribute]bool{
+		RedBG:            true,
+		WhiteBG:          true,
+		DefaultBG:        true,
+		"100":            true,
+		"107":            true,
+		"48;5;200":       true,
+		"48;2;10;20;30":  true,
+		Red:              false,
+		"38;5;200":       false,
+		"97":             false,
+		Bright:           false,
+		"108":            false,
+		Attribute("abc"): false,
+	} {
+		if a.IsBackground() != bg {
+			t.Errorf("%q.IsBackground() != %v", a, bg)
+		}
+	}
+}
+
+func TestWriteClearEOL(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.WriteClearEOL("short")
+	if got := f.written(); got != "short\x1b[K" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestDim(t *testing.T) {
+	if got := string(Set(Red.Dim())); got != "\x1b[2;31m" {
+		t.Fatalf("got %q", got)
+	}
+	if got := string(Set(Red.Dim(), BlueBG)); got != "\x1b[2;31;44m" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestIndex(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Index()
+	a.ReverseIndex()
+	a.NextLine()
+	if got := f.written(); got != "\x1bD\x1bM\x1bE" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestWriteAutoReset(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.WriteAutoReset([]byte("plain " + Red.String("red")))
+	if got := f.written(); got != "plain \x1b[31mred\x1b[0m" {
+		t.Fatalf("expected no extra reset, got %q", got)
+	}
+	a.WriteAutoReset(append(Set(Green), "go"...))
+	if got := f.written(); got != "plain \x1b[31mred\x1b[0m\x1b[32mgo\x1b[0m" {
+		t.Fatalf("expected a reset, got %q", got)
+	}
+}
+
+func TestReportBeforeData(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "k\x1b[3;4Rj"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	//the report must arrive without Read
+	//being drained at the same time
+	a.QueryCursorPosition()
+	r := <-a.Reports
+	if r.Type != Position || r.Pos.Row != 3 || r.Pos.Col != 4 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "kj" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestSetReportTerminators(t *testing.T) {
+	SetReportTerminators('u')
+	defer SetReportTerminators()
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("a\x1b[?1uz")
+	r := <-a.Reports
+	if r.Type != Custom || r.Final != 'u' || len(r.Params) != 1 || r.Params[0] != 1 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "az" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestStripReportCodes(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+		reports int
+	}{
+		{"plain", "plain", 0},
+		{"a\x1b[2;3Rb", "ab", 1},
+		{"a\x1b[0nb\x1b[3;4Rc", "abc", 2},
+		{"\x1b[0nab\x1b[1;1Rcd\x1b[3nef", "abcdef", 3},
+	} {
+		f := newFakeTerm()
+		a := Wrap(f)
+		go f.send(tc.in)
+		for i := 0; i < tc.reports; i++ {
+			<-a.Reports
+		}
+		buf := make([]byte, 64)
+		n, _ := a.Read(buf)
+		if got := string(buf[:n]); got != tc.out {
+			t.Errorf("%q: read %q, expected %q", tc.in, got, tc.out)
+		}
+		a.Close()
+	}
+}
+
+func TestKeysBeforeReportFinals(t *testing.T) {
+	//keys followed by letters which end reports
+	//are data, not reports
+	for _, in := range []string{
+		"\x1b[5~t",
+		"\x1b[3~c",
+		"\x1b[2~n",
+		"\x1b[6~R",
+		"\x1b[1;2R",
+		"\x1b[1;5Rc",
+	} {
+		f := newFakeTerm()
+		a := Wrap(f)
+		go f.send(in)
+		buf := make([]byte, 64)
+		n, _ := a.Read(buf)
+		if got := string(buf[:n]); got != in {
+			t.Errorf("read %q, expected %q", got, in)
+		}
+		select {
+		case r := <-a.Reports:
+			t.Errorf("%q: unexpected report %+v", in, r)
+		default:
+		}
+		a.Close()
+	}
+}
+
+func TestPositionAnsweringQuery(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[1;2R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	row, col, err := a.GetCursorPosition(time.Second)
+	if err != nil || row != 1 || col != 2 {
+		t.Fatalf("unexpected position %d;%d %v", row, col, err)
+	}
+}
+
+func TestSplitReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go func() {
+		for _, b := range []byte("ab\x1b[24;80Rcd") {
+			f.send(string(b))
+		}
+	}()
+	reports := make(chan *Report, 1)
+	go func() {
+		reports <- <-a.Reports
+	}()
+	got := ""
+	buf := make([]byte, 8)
+	for len(got) < 4 {
+		n, _ := a.Read(buf)
+		got += string(buf[:n])
+	}
+	if got != "abcd" {
+		t.Fatalf("unexpected data %q", got)
+	}
+	if r := <-reports; r.Type != Position || r.Pos.Row != 24 || r.Pos.Col != 80 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestSplitReportTimeout(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	//a lone escape (the escape key) is passed on after a while
+	go f.send("x\x1b")
+	got := ""
+	buf := make([]byte, 8)
+	for len(got) < 2 {
+		n, _ := a.Read(buf)
+		got += string(buf[:n])
+	}
+	if got != "x\x1b" {
+		t.Fatalf("unexpected data %q", got)
+	}
+}
+
+func TestLineWrap(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableLineWrap()
+	a.DisableLineWrap()
+	if got, want := f.written(), "\x1b[?7h\x1b[?7l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestPrinterStatus(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.QueryPrinterStatus()
+	if got := f.written(); got != "\x1b[?15n" {
+		t.Fatalf("unexpected query %q", got)
+	}
+	go f.send("\x1b[?10n\x1b[?13n\x1b[0n")
+	for _, want := range []int{PrinterReady, NoPrinter} {
+		r := <-a.Reports
+		if r.Type != PrinterStatus || r.Code != want {
+			t.Fatalf("unexpected report %+v", r)
+		}
+	}
+	if r := <-a.Reports; r.Type != OK {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestMalformedPositionReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("\x1b[R\x1b[;Rok")
+	//a report would block the data behind it
+	read := make(chan string)
+	go func() {
+		buf := make([]byte, 8)
+		n, _ := a.Read(buf)
+		read <- string(buf[:n])
+	}()
+	select {
+	case got := <-read:
+		if got != "ok" {
+			t.Fatalf("unexpected data %q", got)
+		}
+	case r := <-a.Reports:
+		t.Fatalf("unexpected report %+v", r)
+	case <-time.After(time.Second):
+		t.Fatal("read blocked")
+	}
+}
+
+func TestGotoForce(t *testing.T) {
+	if got := string(Goto(12, 3)); got != "\x1b[12;3H" {
+		t.Errorf("Goto = %q", got)
+	}
+	if got := string(Force(12, 3)); got != "\x1b[12;3f" {
+		t.Errorf("Force = %q", got)
+	}
+}
+
+func TestExtendedColors(t *testing.T) {
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{string(Set(FG256(208))), "\x1b[38;5;208m"},
+		{string(Set(BG256(0))), "\x1b[48;5;0m"},
+		{string(Set(FGRGB(255, 128, 0))), "\x1b[38;2;255;128;0m"},
+		{string(Set(Bright, BGRGB(1, 2, 3), FG256(99))), "\x1b[1;48;2;1;2;3;38;5;99m"},
+		{string(Set(Color256(208), BGColor256(17))), "\x1b[38;5;208;48;5;17m"},
+		{string(Set(RGB(9, 8, 7), Underscore)), "\x1b[38;2;9;8;7;4m"},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, expected %q", tc.got, tc.want)
+		}
+	}
+	if !BGRGB(1, 2, 3).IsBackground() || FG256(1).IsBackground() {
+		t.Error("unexpected IsBackground")
+	}
+}
+
+func TestDone(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	select {
+	case <-a.Done():
+		t.Fatal("done before EOF")
+	default:
+	}
+	f.w.Close()
+	select {
+	case <-a.Done():
+	case <-time.After(time.Second):
+		t.Fatal("not done after EOF")
+	}
+	if _, err := a.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestGetCursorPosition(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[0n\x1b[12;34R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	other := make(chan *Report, 1)
+	go func() {
+		other <- <-a.Reports
+	}()
+	row, col, err := a.GetCursorPosition(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row != 12 || col != 34 {
+		t.Fatalf("unexpected position %d,%d", row, col)
+	}
+	if r := <-other; r.Type != OK {
+		t.Fatalf("expected the other report on Reports, got %+v", r)
+	}
+}
+
+func TestCursorPosition(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[0n\x1b[3;4R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	row, col, err := a.CursorPosition(context.Background())
+	if err != nil || row != 3 || col != 4 {
+		t.Fatalf("unexpected position %d,%d %v", row, col, err)
+	}
+	if r := <-a.Reports; r.Type != OK {
+		t.Fatalf("expected the other report on Reports, got %+v", r)
+	}
+	f.reply = nil
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := a.CursorPosition(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to pass, got %v", err)
+	}
+}
+
+func TestUndrainedReports(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go func() {
+		for i := 0; i < ReportsBuffer+10; i++ {
+			f.send(fmt.Sprintf("\x1b[%d;1R", i+1))
+		}
+		f.send("data")
+	}()
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "data" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	//the oldest were dropped
+	if r := <-a.Reports; r.Pos.Row != 11 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if n := len(a.Reports); n != ReportsBuffer-1 {
+		t.Fatalf("expected %d queued reports, got %d", ReportsBuffer-1, n)
+	}
+}
+
+func TestErase(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EraseEndOfLine()
+	a.EraseStartOfLine()
+	a.EraseLine()
+	a.EraseDown()
+	a.EraseUp()
+	a.EraseScreen()
+	if got, want := f.written(), "\x1b[K\x1b[1K\x1b[2K\x1b[J\x1b[1J\x1b[2J"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestSaveRestoreCursor(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.SaveCursor()
+	a.RestoreCursor()
+	a.SaveCursorAttrs()
+	a.RestoreCursorAttrs()
+	if got, want := f.written(), "\x1b[s\x1b[u\x1b7\x1b8"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestScrollRegion(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.ScrollRegion(2, 23)
+	a.ScrollRegion(5, 5)
+	a.ScrollScreen()
+	a.ScrollDown()
+	a.ScrollUp()
+	if got, want := f.written(), "\x1b[2;23r\x1b[r\x1b[r\x1bD\x1bM"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestScrollLines(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.SetScrollRegion(3, 10)
+	a.ScrollLinesUp(2)
+	a.ScrollLinesUp(0)
+	a.ScrollLinesDown(1)
+	a.ScrollLinesDown(0)
+	a.ResetScrollRegion()
+	if got, want := f.written(), "\x1b[3;10r\x1b[2S\x1b[1T\x1b[r"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if b := ScrollLinesUp(0); b != nil {
+		t.Fatalf("expected nothing for 0, got %q", b)
+	}
+}
+
+func TestDeviceAttributes(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("a\x1b[?1;2cb\x1b[0c\x1b[120c")
+	r := <-a.Reports
+	if r.Type != Code || r.Code != 1 || r.Text != "?1;2" || fmt.Sprint(r.Params) != "[1 2]" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if r := <-a.Reports; r.Type != Code || r.Code != 0 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if r := <-a.Reports; r.Type != Code || r.Code != 12 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "ab" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestSecondaryAndModeReports(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.QuerySecondaryCode()
+	if got := f.written(); got != "\x1b[>c" {
+		t.Fatalf("got %q", got)
+	}
+	go f.send("a\x1b[>41;354;0cb\x1b[?2026;2$yc\x1b[4;1$y\x1b[8;24;80t")
+	if r := <-a.Reports; r.Type != SecondaryCode || r.Code != 41 || fmt.Sprint(r.Params) != "[41 354 0]" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if r := <-a.Reports; r.Type != ModeStatus || r.Code != 2026 || r.Params[1] != ModeReset || r.Text != "?2026;2" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if r := <-a.Reports; r.Type != ModeStatus || r.Code != 4 || r.Params[1] != ModeSet || r.Text != "4;1" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if r := <-a.Reports; r.Type != Window || fmt.Sprint(r.Params) != "[8 24 80]" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "abc" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestWrapString(t *testing.T) {
+	reset := string(Set(Reset))
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{WrapString("hi", Red, Bright), string(Set(Red, Bright)) + "hi" + reset},
+		{WrapString("hi"), "hi"},
+		{Colorize(Green.String("go"), Bright), string(Set(Bright)) + Green.String("go")},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, expected %q", tc.got, tc.want)
+		}
+	}
+}
+
+func TestDeviceQueries(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.QueryDeviceCode()
+	a.QueryDeviceStatus()
+	a.ResetDevice()
+	if got, want := f.written(), "\x1b[c\x1b[5n\x1bc"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestWriteErr(t *testing.T) {
+	a := Wrap(nopWriter{io.ErrClosedPipe})
+	if err := a.WriteErr(); err != nil {
+		t.Fatalf("expected no error yet, got %v", err)
+	}
+	a.Goto(1, 1)
+	a.Set(Red)
+	if err := a.WriteErr(); err != io.ErrClosedPipe {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+}
+
+func TestReadLeftover(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	go f.send("hello\x1b[0nworld")
+	<-a.Reports
+	var got []byte
+	buf := make([]byte, 3)
+	for len(got) < 10 {
+		n, err := a.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if string(got) != "helloworld" {
+		t.Fatalf("got %q", got)
+	}
+	//left over data is still read after the stream ends
+	go f.send("abcd")
+	if n, err := a.Read(buf); string(buf[:n]) != "abc" || err != nil {
+		t.Fatalf("unexpected %q %v", buf[:n], err)
+	}
+	f.w.Close()
+	<-a.Done()
+	if n, err := a.Read(buf); string(buf[:n]) != "d" || err != nil {
+		t.Fatalf("unexpected %q %v", buf[:n], err)
+	}
+	if _, err := a.Read(buf); err == nil {
+		t.Fatal("expected the read error once drained")
+	}
+}
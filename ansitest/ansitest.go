@@ -0,0 +1,141 @@
+// Package ansitest provides a fake terminal for testing code
+// which uses ansi.Wrap
+package ansitest
+
+import (
+	"bytes"
+	"net"
+	"sync"
+
+	"github.com/jpillora/ansi"
+)
+
+// FakeTerminal is the application end of a net.Pipe, its other end
+// played by a terminal which records everything written to it and
+// answers the queries it knows with canned reports. By default it
+// answers the device status, cursor position, device attributes
+// and window size queries.
+type FakeTerminal struct {
+	net.Conn
+	term    net.Conn
+	mu      sync.Mutex
+	written bytes.Buffer
+	seqs    []string
+	replies map[string]string
+	input   chan string
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New returns a FakeTerminal
+func New() *FakeTerminal {
+	app, term := net.Pipe()
+	f := &FakeTerminal{
+		Conn: app,
+		term: term,
+		replies: map[string]string{
+			string(ansi.QueryDeviceStatus):   "\x1b[0n",
+			string(ansi.QueryCursorPosition): "\x1b[1;1R",
+			string(ansi.QueryCode):           "\x1b[?62;22c",
+			string(ansi.QueryWindowSize):     "\x1b[8;24;80t",
+		},
+		input: make(chan string, 64),
+		done:  make(chan struct{}),
+	}
+	f.wg.Add(2)
+	go f.record()
+	go f.send()
+	return f
+}
+
+// Respond sets the reply to query, which is written
+// to the application each time it writes query.
+// An empty reply leaves the query unanswered.
+func (f *FakeTerminal) Respond(query, reply string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if reply == "" {
+		delete(f.replies, query)
+		return
+	}
+	f.replies[query] = reply
+}
+
+// Send writes s to the application,
+// as if typed into the terminal
+func (f *FakeTerminal) Send(s string) {
+	select {
+	case f.input <- s:
+	case <-f.done:
+	}
+}
+
+// Written returns everything written so far
+func (f *FakeTerminal) Written() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.written.String()
+}
+
+// Sequences returns the escape sequences written so far, in order
+func (f *FakeTerminal) Sequences() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.seqs...)
+}
+
+// Reset forgets what was written so far
+func (f *FakeTerminal) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.written.Reset()
+	f.seqs = nil
+}
+
+// Close closes both ends of the pipe
+func (f *FakeTerminal) Close() error {
+	err := f.Conn.Close()
+	f.term.Close()
+	f.wg.Wait()
+	return err
+}
+
+// record reads what the application writes, answering queries.
+// Replies go through send, so a slow reader never holds up writes.
+func (f *FakeTerminal) record() {
+	defer f.wg.Done()
+	defer close(f.done)
+	d := ansi.NewDecoder(f.term)
+	for {
+		t, err := d.Next()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		f.written.Write(t.Raw)
+		var reply string
+		if t.Type != ansi.TextToken {
+			f.seqs = append(f.seqs, string(t.Raw))
+			reply = f.replies[string(t.Raw)]
+		}
+		f.mu.Unlock()
+		if reply != "" {
+			f.Send(reply)
+		}
+	}
+}
+
+// send writes the input and replies to the application
+func (f *FakeTerminal) send() {
+	defer f.wg.Done()
+	for {
+		select {
+		case s := <-f.input:
+			if _, err := f.term.Write([]byte(s)); err != nil {
+				return
+			}
+		case <-f.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ansitest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jpillora/ansi"
+)
+
+func TestFakeTerminal(t *testing.T) {
+	f := New()
+	a := ansi.Wrap(f)
+	defer a.Close()
+	a.Goto(3, 4)
+	a.Set(ansi.Red)
+	a.Write([]byte("hi"))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	row, col, err := a.CursorPosition(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row != 1 || col != 1 {
+		t.Fatalf("unexpected position %d,%d", row, col)
+	}
+	want := []string{"\x1b[3;4H", "\x1b[31m", "\x1b[6n"}
+	if got := f.Sequences(); len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if got := f.Written(); got != "\x1b[3;4H\x1b[31mhi\x1b[6n" {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
+
+func TestFakeTerminalRespond(t *testing.T) {
+	f := New()
+	f.Respond(string(ansi.QueryCursorPosition), "\x1b[12;34R")
+	f.Respond(string(ansi.QueryDeviceStatus), "")
+	a := ansi.Wrap(f)
+	defer a.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	row, col, err := a.CursorPosition(ctx)
+	if err != nil || row != 12 || col != 34 {
+		t.Fatalf("got %d,%d, %v", row, col, err)
+	}
+	f.Reset()
+	f.Send("typed")
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "typed" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	if f.Written() != "" || f.Sequences() != nil {
+		t.Fatalf("expected nothing after Reset, got %q", f.Written())
+	}
+}
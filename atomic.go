@@ -0,0 +1,43 @@
+package ansi
+
+// WriteAtomic writes p, except for an escape sequence p ends in the
+// middle of. That is held back and written along with the rest of
+// it on the next call, so the underlying writer never sees a split
+// sequence, even when a stream is written in arbitrary chunks. n is
+// len(p) on success, including the bytes held back. Flush and Close
+// write out what is held as it is. Mixing Write and WriteAtomic can
+// reorder the held bytes.
+func (a *Ansi) WriteAtomic(p []byte) (n int, err error) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	select {
+	case <-a.closed:
+		a.lastWriteErr = ErrClosed
+		return 0, ErrClosed
+	default:
+	}
+	b := append(a.partial, p...)
+	a.partial = nil
+	if i := unfinished(b, -1); i >= 0 {
+		a.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	if len(b) > 0 {
+		if _, err = a.output(b); err != nil {
+			a.lastWriteErr = err
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// writePartial writes out the bytes WriteAtomic held back
+func (a *Ansi) writePartial() error {
+	if len(a.partial) == 0 {
+		return nil
+	}
+	p := a.partial
+	a.partial = nil
+	_, err := a.output(p)
+	return err
+}
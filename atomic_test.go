@@ -0,0 +1,54 @@
+package ansi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	defer a.Close()
+	for _, p := range []string{"ab\x1b[3", "1mcd\x1b", "]8;;x", "\x1b\\", "e"} {
+		if n, err := a.WriteAtomic([]byte(p)); n != len(p) || err != nil {
+			t.Fatalf("unexpected %d %v", n, err)
+		}
+	}
+	want := []string{"ab", "\x1b[31mcd", "\x1b]8;;x\x1b\\", "e"}
+	if len(w) != len(want) {
+		t.Fatalf("got %q, expected %q", w, want)
+	}
+	for i := range want {
+		if string(w[i]) != want[i] {
+			t.Fatalf("got %q, expected %q", w, want)
+		}
+	}
+}
+
+func TestWriteAtomicLong(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	defer a.Close()
+	payload := strings.Repeat("A", 3*maxCarry)
+	for _, p := range []string{"\x1b]52;c;", payload, "\x07"} {
+		a.WriteAtomic([]byte(p))
+	}
+	if len(w) != 1 || string(w[0]) != "\x1b]52;c;"+payload+"\x07" {
+		t.Fatalf("expected a single write, got %d", len(w))
+	}
+}
+
+func TestWriteAtomicFlush(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	a.WriteAtomic([]byte("ab\x1b[3"))
+	a.Flush()
+	if len(w) != 2 || string(w[1]) != "\x1b[3" {
+		t.Fatalf("expected the held bytes on Flush, got %q", w)
+	}
+	a.WriteAtomic([]byte("\x1b]2;x"))
+	a.Close()
+	if len(w) != 3 || string(w[2]) != "\x1b]2;x" {
+		t.Fatalf("expected the held bytes on Close, got %q", w)
+	}
+}
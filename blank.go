@@ -0,0 +1,24 @@
+package ansi
+
+// Begin Synchronized Output	<ESC>[?2026h
+// End Synchronized Output	<ESC>[?2026l
+var BeginSync = []byte{Esc, '[', '?', '2', '0', '2', '6', 'h'}
+var EndSync = []byte{Esc, '[', '?', '2', '0', '2', '6', 'l'}
+
+func (a *Ansi) BeginSync() {
+	a.Write(BeginSync)
+}
+
+func (a *Ansi) EndSync() {
+	a.Write(EndSync)
+}
+
+// Blank holds back the screen update while fn redraws it, using
+// synchronized output. Terminals without mode 2026 ignore it and
+// show the redraw as it happens. Private mode 80 is not used, it
+// is sixel display mode (DECSDM) rather than screen blanking.
+func (a *Ansi) Blank(fn func()) {
+	a.Write(BeginSync)
+	defer a.Write(EndSync)
+	fn()
+}
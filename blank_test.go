@@ -0,0 +1,16 @@
+package ansi
+
+import "testing"
+
+func TestBlank(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Blank(func() {
+		a.Write([]byte("redraw"))
+	})
+	want := "\x1b[?2026h" + "redraw" + "\x1b[?2026l"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package ansi
+
+import "strings"
+
+// BoxStyle selects the characters Box draws with
+type BoxStyle int
+
+const (
+	//BoxASCII uses +, - and |, like RenderTable
+	BoxASCII BoxStyle = iota
+	//BoxSingle uses single line box drawing ┌─┐
+	BoxSingle
+	//BoxRounded is BoxSingle with rounded corners ╭─╮
+	BoxRounded
+	//BoxDEC uses the DEC special graphics set, for terminals
+	//without the unicode box drawing characters. It has no
+	//rounded corners.
+	BoxDEC
+)
+
+// Select DEC Special Graphics	<ESC>(0
+// Select ASCII			<ESC>(B
+var DECGraphics = []byte{Esc, '(', '0'}
+var ASCIIGraphics = []byte{Esc, '(', 'B'}
+
+// boxChars are the corners (top left, top right, bottom
+// left, bottom right) then the horizontal and vertical edges
+var boxChars = map[BoxStyle][6]string{
+	BoxASCII:   {"+", "+", "+", "+", "-", "|"},
+	BoxSingle:  {"┌", "┐", "└", "┘", "─", "│"},
+	BoxRounded: {"╭", "╮", "╰", "╯", "─", "│"},
+	BoxDEC:     {"l", "k", "m", "j", "q", "x"},
+}
+
+// Box draws a border in style around lines, which are padded to
+// the width of the widest, measured with VisibleLength
+func Box(lines []string, style BoxStyle) []byte {
+	c, ok := boxChars[style]
+	if !ok {
+		c = boxChars[BoxASCII]
+	}
+	edge := func(s string) string {
+		if style == BoxDEC {
+			return string(DECGraphics) + s + string(ASCIIGraphics)
+		}
+		return s
+	}
+	w := 0
+	for _, l := range lines {
+		if n := VisibleLength(l); n > w {
+			w = n
+		}
+	}
+	var b strings.Builder
+	b.WriteString(edge(c[0] + strings.Repeat(c[4], w) + c[1]))
+	b.WriteByte('\n')
+	for _, l := range lines {
+		b.WriteString(edge(c[5]))
+		b.WriteString(l)
+		b.WriteString(strings.Repeat(" ", w-VisibleLength(l)))
+		b.WriteString(edge(c[5]))
+		b.WriteByte('\n')
+	}
+	b.WriteString(edge(c[2] + strings.Repeat(c[4], w) + c[3]))
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
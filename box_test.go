@@ -0,0 +1,23 @@
+package ansi
+
+import "testing"
+
+func TestBoxRounded(t *testing.T) {
+	got := string(Box([]string{"hi", Red.String("a")}, BoxRounded))
+	want := "╭──╮\n" +
+		"│hi│\n" +
+		"│" + Red.String("a") + " │\n" +
+		"╰──╯\n"
+	if got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestBoxDEC(t *testing.T) {
+	dec := func(s string) string { return string(DECGraphics) + s + string(ASCIIGraphics) }
+	got := string(Box([]string{"x"}, BoxDEC))
+	want := dec("lqk") + "\n" + dec("x") + "x" + dec("x") + "\n" + dec("mqj") + "\n"
+	if got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
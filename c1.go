@@ -0,0 +1,36 @@
+package ansi
+
+// Use8BitControls, when on, rewrites the CSI (<ESC>[), OSC (<ESC>]),
+// DCS (<ESC>P) and ST (<ESC>\) introducers in all output to their
+// single byte C1 forms (0x9B, 0x9D, 0x90 and 0x9C). Only use this
+// with terminals and protocols which expect them, since the C1
+// bytes are not valid UTF-8.
+func (a *Ansi) Use8BitControls(on bool) {
+	a.wmu.Lock()
+	a.c1 = on
+	a.wmu.Unlock()
+}
+
+// to8Bit returns a copy of p with the
+// 7-bit introducers replaced
+func to8Bit(p []byte) []byte {
+	out := make([]byte, 0, len(p))
+	for i := 0; i < len(p); i++ {
+		if p[i] == Esc && i+1 < len(p) {
+			if c, ok := c1Controls[p[i+1]]; ok {
+				out = append(out, c)
+				i++
+				continue
+			}
+		}
+		out = append(out, p[i])
+	}
+	return out
+}
+
+var c1Controls = map[byte]byte{
+	'[':  0x9b,
+	']':  0x9d,
+	'P':  0x90,
+	'\\': 0x9c,
+}
@@ -0,0 +1,19 @@
+package ansi
+
+import "testing"
+
+func TestUse8BitControls(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.SetTitle("hi")
+	a.Use8BitControls(true)
+	a.SetTitle("hi")
+	a.Goto(1, 2)
+	a.Use8BitControls(false)
+	a.Goto(1, 2)
+	want := "\x1b]2;hi\x1b\\" + "\x9d2;hi\x9c" + "\x9b1;2H" + "\x1b[1;2H"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
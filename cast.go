@@ -0,0 +1,150 @@
+package ansi
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// castHeader is the first line of an asciicast v2 file
+type castHeader struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// Recorder is a ReadWriter over an Ansi which records what is
+// written, and optionally what is read, as an asciicast v2 (the
+// asciinema format) session: a header line, then a JSON line
+// [seconds, "o" or "i", data] per Write or Read
+type Recorder struct {
+	a     *Ansi
+	mu    sync.Mutex
+	cast  io.Writer
+	input bool
+	start time.Time
+	err   error
+}
+
+// NewRecorder returns a Recorder writing the session to cast, for a
+// terminal of width by height, recording reads too when input is set
+func NewRecorder(a *Ansi, cast io.Writer, width, height int, input bool) (*Recorder, error) {
+	r := &Recorder{a: a, cast: cast, input: input, start: now()}
+	b, _ := json.Marshal(castHeader{Version: 2, Width: width, Height: height, Timestamp: r.start.Unix()})
+	if _, err := cast.Write(append(b, '\n')); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) Write(p []byte) (int, error) {
+	n, err := r.a.Write(p)
+	r.event("o", p[:n])
+	return n, err
+}
+
+func (r *Recorder) Read(p []byte) (int, error) {
+	n, err := r.a.Read(p)
+	if r.input {
+		r.event("i", p[:n])
+	}
+	return n, err
+}
+
+// Err returns the first error writing the session
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// event records data, the first error
+// writing the session stops the recording
+func (r *Recorder) event(kind string, data []byte) {
+	if len(data) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	t := now().Sub(r.start).Seconds()
+	b, _ := json.Marshal([]interface{}{t, kind, string(data)})
+	_, r.err = r.cast.Write(append(b, '\n'))
+}
+
+// ErrInvalidCast is returned by Player.Play for a
+// file which is not an asciicast v2 session
+var ErrInvalidCast = errors.New("Invalid asciicast")
+
+// Player replays an asciicast v2 session
+type Player struct {
+	//Speed multiplies the pace of the session,
+	//1 (or 0) is the recorded pace
+	Speed float64
+	//MaxIdle, when set, caps the pauses between events
+	MaxIdle time.Duration
+	cast    io.Reader
+}
+
+// NewPlayer returns a Player reading the session from cast
+func NewPlayer(cast io.Reader) *Player {
+	return &Player{cast: cast}
+}
+
+// Play writes the output of the session to w,
+// pausing between events as recorded
+func (p *Player) Play(w io.Writer) error {
+	s := bufio.NewScanner(p.cast)
+	s.Buffer(nil, maxSequence)
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return err
+		}
+		return ErrInvalidCast
+	}
+	var h castHeader
+	if err := json.Unmarshal(s.Bytes(), &h); err != nil || h.Version != 2 {
+		return ErrInvalidCast
+	}
+	speed := p.Speed
+	if speed <= 0 {
+		speed = 1
+	}
+	last := 0.0
+	for s.Scan() {
+		if len(s.Bytes()) == 0 {
+			continue
+		}
+		var event []interface{}
+		if err := json.Unmarshal(s.Bytes(), &event); err != nil || len(event) != 3 {
+			return ErrInvalidCast
+		}
+		t, ok1 := event[0].(float64)
+		kind, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return ErrInvalidCast
+		}
+		if kind != "o" {
+			continue
+		}
+		pause := time.Duration((t - last) / speed * float64(time.Second))
+		if p.MaxIdle > 0 && pause > p.MaxIdle {
+			pause = p.MaxIdle
+		}
+		if pause > 0 {
+			sleep(pause)
+		}
+		last = t
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
@@ -0,0 +1,72 @@
+package ansi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	clock := time.Unix(1000, 0)
+	now = func() time.Time { return clock }
+	defer func() { now = time.Now }()
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	var cast bytes.Buffer
+	r, err := NewRecorder(a, &cast, 80, 24, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Write([]byte("hi\x1b[1m"))
+	clock = clock.Add(1500 * time.Millisecond)
+	go f.send("y")
+	buf := make([]byte, 4)
+	if n, _ := r.Read(buf); string(buf[:n]) != "y" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	want := `{"version":2,"width":80,"height":24,"timestamp":1000}` + "\n" +
+		`[0,"o","hi\u001b[1m"]` + "\n" +
+		`[1.5,"i","y"]` + "\n"
+	if cast.String() != want {
+		t.Fatalf("got %q, expected %q", cast.String(), want)
+	}
+	if f.written() != "hi\x1b[1m" {
+		t.Fatalf("unexpected output %q", f.written())
+	}
+}
+
+func TestPlayer(t *testing.T) {
+	var pauses []time.Duration
+	sleep = func(d time.Duration) { pauses = append(pauses, d) }
+	defer func() { sleep = time.Sleep }()
+	cast := `{"version":2,"width":80,"height":24}
+[0.5,"o","a"]
+[1.0,"i","typed"]
+[2.0,"o","b"]
+[10.0,"o","c"]
+`
+	p := NewPlayer(strings.NewReader(cast))
+	p.Speed = 2
+	p.MaxIdle = time.Second
+	var out bytes.Buffer
+	if err := p.Play(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "abc" {
+		t.Fatalf("got %q", out.String())
+	}
+	want := []time.Duration{250 * time.Millisecond, 750 * time.Millisecond, time.Second}
+	if len(pauses) != len(want) {
+		t.Fatalf("got pauses %v, expected %v", pauses, want)
+	}
+	for i := range want {
+		if pauses[i] != want[i] {
+			t.Fatalf("got pauses %v, expected %v", pauses, want)
+		}
+	}
+	if err := NewPlayer(strings.NewReader(`{"version":1}`)).Play(&out); err != ErrInvalidCast {
+		t.Fatalf("expected ErrInvalidCast, got %v", err)
+	}
+}
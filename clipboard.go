@@ -0,0 +1,43 @@
+package ansi
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Set Clipboard		<ESC>]52;c;{base64}<ESC>\
+func Clipboard(s string) []byte {
+	b := append([]byte{Esc, ']', '5', '2', ';', 'c', ';'}, base64.StdEncoding.EncodeToString([]byte(s))...)
+	return append(b, Esc, '\\')
+}
+
+// SetClipboard copies s to the clipboard, where the
+// terminal allows it (most only allow setting)
+func (a *Ansi) SetClipboard(s string) {
+	a.Write(Clipboard(s))
+}
+
+// Query Clipboard	<ESC>]52;c;?<BEL>
+// Report Clipboard	<ESC>]52;c;{base64}<BEL>
+var QueryClipboard = []byte{Esc, ']', '5', '2', ';', 'c', ';', '?', 7}
+
+// ReadClipboard asks the terminal for the content of the clipboard
+func (a *Ansi) ReadClipboard(timeout time.Duration) (string, error) {
+	r, err := a.query(QueryClipboard, func(r *Report) bool {
+		return r.Type == OSC && r.Code == 52
+	}, timeout)
+	if err != nil {
+		return "", err
+	}
+	data := r.Text
+	if i := strings.IndexByte(data, ';'); i >= 0 {
+		data = data[i+1:]
+	}
+	b, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", errors.New("Invalid clipboard report")
+	}
+	return string(b), nil
+}
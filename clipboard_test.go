@@ -0,0 +1,28 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClipboard(t *testing.T) {
+	if got := string(Clipboard("hi there")); got != "\x1b]52;c;aGkgdGhlcmU=\x1b\\" {
+		t.Fatalf("got %q", got)
+	}
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == string(QueryClipboard) {
+			return "\x1b]52;c;Y29waWVk\x07"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	s, err := a.ReadClipboard(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != "copied" {
+		t.Fatalf("got %q", s)
+	}
+}
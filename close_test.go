@@ -0,0 +1,61 @@
+package ansi
+
+import (
+	"io"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// blockingRWC hands out one chunk of input, then
+// blocks reading until it is closed
+type blockingRWC struct {
+	input  chan []byte
+	closed chan struct{}
+}
+
+func (b *blockingRWC) Read(p []byte) (int, error) {
+	select {
+	case in := <-b.input:
+		return copy(p, in), nil
+	case <-b.closed:
+		return 0, io.EOF
+	}
+}
+
+func (b *blockingRWC) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+func (b *blockingRWC) Close() error {
+	close(b.closed)
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	rwc := &blockingRWC{input: make(chan []byte, 1), closed: make(chan struct{})}
+	//nobody reads this, so the reader is stuck handing it over
+	rwc.input <- []byte("unread")
+	a := Wrap(rwc)
+	time.Sleep(10 * time.Millisecond)
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Close(); err != ErrClosed {
+		t.Fatalf("expected ErrClosed closing twice, got %v", err)
+	}
+	if _, err := a.Write([]byte("x")); err != ErrClosed {
+		t.Fatalf("expected ErrClosed writing, got %v", err)
+	}
+	<-a.Done()
+	if _, err := a.Read(make([]byte, 8)); err == nil {
+		t.Fatal("expected an error reading")
+	}
+	for i := 0; runtime.NumGoroutine() > baseline; i++ {
+		if i == 100 {
+			t.Fatalf("expected %d goroutines, got %d", baseline, runtime.NumGoroutine())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
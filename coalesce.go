@@ -0,0 +1,86 @@
+package ansi
+
+import (
+	"io"
+	"strings"
+)
+
+// Coalescer is an io.Writer which merges consecutive SGR
+// sequences into a single sequence. Pending attributes are
+// written out along with the next non-SGR bytes, or on Flush.
+type Coalescer struct {
+	w       io.Writer
+	pending []string
+}
+
+// NewCoalescer wraps w in a Coalescer
+func NewCoalescer(w io.Writer) *Coalescer {
+	return &Coalescer{w: w}
+}
+
+// Write p, holding back any trailing SGR sequences
+func (c *Coalescer) Write(p []byte) (int, error) {
+	var out []byte
+	s := string(p)
+	for i := 0; i < len(s); {
+		if params, n := sgrParams(s[i:]); n > 0 {
+			c.pending = append(c.pending, params)
+			i += n
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] != Esc {
+			j++
+		}
+		out = c.appendPending(out)
+		out = append(out, s[i:j]...)
+		i = j
+	}
+	if len(out) > 0 {
+		if _, err := c.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes any pending attributes
+func (c *Coalescer) Flush() error {
+	if len(c.pending) == 0 {
+		return nil
+	}
+	_, err := c.w.Write(c.appendPending(nil))
+	return err
+}
+
+func (c *Coalescer) appendPending(b []byte) []byte {
+	if len(c.pending) == 0 {
+		return b
+	}
+	b = append(b, Esc, '[')
+	b = append(b, strings.Join(c.pending, ";")...)
+	b = append(b, 'm')
+	c.pending = c.pending[:0]
+	return b
+}
+
+// sgrParams returns the parameters and length of the
+// complete SGR sequence at the start of s, if any.
+// An empty parameter list is returned as a reset.
+func sgrParams(s string) (string, int) {
+	if len(s) < 3 || s[0] != Esc || s[1] != '[' {
+		return "", 0
+	}
+	for i := 2; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == 'm':
+			if i == 2 {
+				return string(Reset), i + 1
+			}
+			return s[2:i], i + 1
+		case c != ';' && c != ':' && (c < '0' || c > '9'):
+			return "", 0
+		}
+	}
+	return "", 0
+}
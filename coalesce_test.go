@@ -0,0 +1,34 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestCoalescer(t *testing.T) {
+	var out bytes.Buffer
+	c := NewCoalescer(&out)
+	c.Write(Set(Red))
+	c.Write(Set(Bright))
+	if out.Len() != 0 {
+		t.Fatalf("expected sets to be held back, got %q", out.String())
+	}
+	c.Write([]byte("hi"))
+	c.Write(Set(Reset))
+	if got := out.String(); got != "\x1b[31;1mhi" {
+		t.Fatalf("got %q", got)
+	}
+	c.Flush()
+	if got := out.String(); got != "\x1b[31;1mhi\x1b[0m" {
+		t.Fatalf("got %q after flush", got)
+	}
+}
+
+func TestCoalescerPassthrough(t *testing.T) {
+	var out bytes.Buffer
+	c := NewCoalescer(&out)
+	c.Write(append(Set(Green), Goto(1, 1)...))
+	if got := out.String(); got != "\x1b[32m\x1b[1;1H" {
+		t.Fatalf("got %q", got)
+	}
+}
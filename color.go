@@ -0,0 +1,91 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Color is a 24-bit RGB color
+type Color struct {
+	R, G, B uint8
+}
+
+// Query Foreground Color	<ESC>]10;?<BEL>
+// Query Background Color	<ESC>]11;?<BEL>
+// Report Color		<ESC>]{10|11};rgb:{RRRR}/{GGGG}/{BBBB}<BEL>
+var QueryForeground = []byte{Esc, ']', '1', '0', ';', '?', 7}
+var QueryBackground = []byte{Esc, ']', '1', '1', ';', '?', 7}
+
+// DefaultColors asks the terminal for its default foreground and
+// background colors. The answers are cached, so later calls
+// return immediately.
+func (a *Ansi) DefaultColors(timeout time.Duration) (fg, bg Color, err error) {
+	a.mu.Lock()
+	cached := a.colors
+	a.mu.Unlock()
+	if cached != nil {
+		return cached[0], cached[1], nil
+	}
+	w := a.wait(func(r *Report) bool {
+		return r.Type == OSC && (r.Code == 10 || r.Code == 11)
+	})
+	defer a.unwait(w)
+	if _, err := a.Write(append(append([]byte{}, QueryForeground...), QueryBackground...)); err != nil {
+		return fg, bg, err
+	}
+	deadline := time.After(timeout)
+	for got := 0; got != 3; {
+		select {
+		case r := <-w.reports:
+			c, ok := parseXColor(r.Text)
+			if !ok {
+				continue
+			}
+			if r.Code == 10 {
+				fg = c
+				got |= 1
+			} else {
+				bg = c
+				got |= 2
+			}
+		case <-deadline:
+			return fg, bg, ErrTimeout
+		}
+	}
+	a.mu.Lock()
+	a.colors = []Color{fg, bg}
+	a.mu.Unlock()
+	return fg, bg, nil
+}
+
+// parseXColor parses the rgb:{R}/{G}/{B} form
+// which has 1 to 4 hex digits per channel
+func parseXColor(s string) (Color, bool) {
+	if !strings.HasPrefix(s, "rgb:") {
+		return Color{}, false
+	}
+	parts := strings.Split(s[4:], "/")
+	if len(parts) != 3 {
+		return Color{}, false
+	}
+	var rgb [3]uint8
+	for i, p := range parts {
+		if len(p) == 0 || len(p) > 4 {
+			return Color{}, false
+		}
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil {
+			return Color{}, false
+		}
+		max := uint64(1)<<(4*uint(len(p))) - 1
+		rgb[i] = uint8(v * 255 / max)
+	}
+	return Color{rgb[0], rgb[1], rgb[2]}, true
+}
+
+// parseOSC is the report of an OSC sequence sent by the terminal
+func (a *Ansi) parseOSC(code, body string) *Report {
+	n, _ := strconv.Atoi(code)
+	return &Report{Type: OSC, Code: n, Text: body}
+}
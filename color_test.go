@@ -0,0 +1,49 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultColors(t *testing.T) {
+	f := newFakeTerm()
+	queries := 0
+	f.reply = func(q string) string {
+		queries++
+		return "\x1b]11;rgb:1e1e/1e1e/2e2e\x07\x1b]10;rgb:ff/cc/00\x1b\\"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	for i := 0; i < 2; i++ {
+		fg, bg, err := a.DefaultColors(time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fg != (Color{0xff, 0xcc, 0x00}) || bg != (Color{0x1e, 0x1e, 0x2e}) {
+			t.Fatalf("unexpected colors %v %v", fg, bg)
+		}
+	}
+	if queries != 1 {
+		t.Fatalf("expected colors to be cached, queried %d times", queries)
+	}
+	if got := f.written(); got != "\x1b]10;?\x07\x1b]11;?\x07" {
+		t.Fatalf("unexpected query %q", got)
+	}
+}
+
+func TestParseXColor(t *testing.T) {
+	for s, c := range map[string]Color{
+		"rgb:f/0/8":          {255, 0, 136},
+		"rgb:ffff/0000/8080": {255, 0, 128},
+		"rgb:12/34/56":       {0x12, 0x34, 0x56},
+	} {
+		if got, ok := parseXColor(s); !ok || got != c {
+			t.Errorf("parseXColor(%q) = %v, expected %v", s, got, c)
+		}
+	}
+	for _, s := range []string{"", "rgb:1/2", "#ffffff", "rgb:xx/00/00"} {
+		if _, ok := parseXColor(s); ok {
+			t.Errorf("parseXColor(%q) should fail", s)
+		}
+	}
+}
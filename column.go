@@ -0,0 +1,145 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Column returns the column the cursor is at, counting from
+// zero, as tracked from everything written. Newlines, carriage
+// returns and absolute moves reset the tracking. The terminal
+// may still disagree, for example after it wraps a long line.
+func (a *Ansi) Column() int {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	return a.col
+}
+
+// track advances the cursor column past p
+func (a *Ansi) track(p []byte) {
+	s := string(p)
+	for i := 0; i < len(s); {
+		switch c := s[i]; c {
+		case Esc:
+			n, _ := sequenceLen(s[i:])
+			a.trackSequence(s[i : i+n])
+			i += n
+			continue
+		case '\n', '\r':
+			a.col = 0
+		case '\t':
+			a.col = nextTab(a.col)
+		case '\b':
+			if a.col > 0 {
+				a.col--
+			}
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		a.col += runeWidth(r)
+		i += n
+	}
+}
+
+// trackSequence applies the cursor moves, saves and
+// restores which affect the column and notes whether a style is
+// active and line wrap is off, mode changes are
+// passed to the guards, all others are ignored
+func (a *Ansi) trackSequence(seq string) {
+	if params, n := sgrParams(seq); n > 0 {
+		last := lastSGR(params)
+		a.styled = last != "" && last != string(Reset)
+		return
+	}
+	switch seq {
+	case string(NextLine):
+		a.col = 0
+		return
+	case string(SaveCursor), string(SaveAttrCursor):
+		a.savedCol = a.col
+		return
+	case string(UnsaveCursor), string(RestoreAttrCursor):
+		a.col = a.savedCol
+		return
+	case string(EnableLineWrap):
+		a.nowrap = false
+		return
+	case string(DisableLineWrap):
+		a.nowrap = true
+		return
+	}
+	if len(seq) < 3 || seq[1] != '[' {
+		return
+	}
+	if f := seq[len(seq)-1]; f == 'h' || f == 'l' {
+		for _, g := range a.guards {
+			g.changed(seq[2:len(seq)-1], f == 'h')
+		}
+	}
+	body := seq[2 : len(seq)-1]
+	arg := func(n int) int {
+		f, next := field(body, 0)
+		for ; n > 0; n-- {
+			if next < 0 {
+				return 1
+			}
+			f, next = field(body, next)
+		}
+		if f == "" {
+			return 1
+		}
+		v, _ := strconv.Atoi(f)
+		return v
+	}
+	switch seq[len(seq)-1] {
+	case 'H', 'f':
+		a.col = arg(1) - 1
+	case 'G':
+		a.col = arg(0) - 1
+	case 'C':
+		a.col += arg(0)
+	case 'D':
+		a.col -= arg(0)
+	}
+	if a.col < 0 {
+		a.col = 0
+	}
+}
+
+// field returns the ;-separated field of s starting at i,
+// and the start of the next one, -1 after the last
+func field(s string, i int) (string, int) {
+	if j := strings.IndexByte(s[i:], ';'); j >= 0 {
+		return s[i : i+j], i + j + 1
+	}
+	return s[i:], -1
+}
+
+// lastSGR returns the last attribute of the SGR parameters,
+// like the last of splitSGR but without allocating
+func lastSGR(params string) string {
+	last := ""
+	for i := 0; i >= 0; {
+		start := i
+		var f string
+		f, i = field(params, i)
+		if (f == "38" || f == "48" || f == "58") && i >= 0 {
+			n := 0
+			switch next, _ := field(params, i); next {
+			case "5":
+				n = 2
+			case "2":
+				n = 4
+			}
+			for ; n > 0 && i >= 0; n-- {
+				_, i = field(params, i)
+			}
+		}
+		if i < 0 {
+			last = params[start:]
+		} else {
+			last = params[start : i-1]
+		}
+	}
+	return last
+}
@@ -0,0 +1,74 @@
+package ansi
+
+import "testing"
+
+func TestColumn(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	for _, tc := range []struct {
+		write string
+		col   int
+	}{
+		{"hello", 5},
+		{Red.String(" 日本"), 10},
+		{"\r", 0},
+		{"abc\ndef", 3},
+		{string(Goto(4, 20)), 19},
+		{"x\b\b", 18},
+		{"\x1b[5C", 23},
+		{"\x1b[D", 22},
+		{"\x1b[G", 0},
+		{"abc\x1bE", 0},
+		{"ab\x1b7\x1b[10Cx\x1b8", 2},
+		{"\x1b[s\x1b[G\x1b[u", 2},
+	} {
+		a.Write([]byte(tc.write))
+		if c := a.Column(); c != tc.col {
+			t.Fatalf("after %q: column %d, expected %d", tc.write, c, tc.col)
+		}
+	}
+}
+
+func TestColumnTabs(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	for _, tc := range []struct {
+		write string
+		col   int
+	}{
+		{"\t", 8},
+		{"abc\t", 16},
+		{"12345678\t", 32},
+		{"\r" + Red.String("ab") + "\t", 8},
+	} {
+		a.Write([]byte(tc.write))
+		if c := a.Column(); c != tc.col {
+			t.Fatalf("after %q: column %d, expected %d", tc.write, c, tc.col)
+		}
+	}
+	defer func(w int) { TabWidth = w }(TabWidth)
+	TabWidth = 4
+	a.Write([]byte("\rab\t"))
+	if c := a.Column(); c != 4 {
+		t.Fatalf("column %d, expected 4", c)
+	}
+	if l := VisibleLength("a\tb\t"); l != 8 {
+		t.Fatalf("VisibleLength %d, expected 8", l)
+	}
+}
+
+func TestColumnAfterSize(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[24;80R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	a.Write([]byte("abc"))
+	if _, _, err := a.Size(); err != nil {
+		t.Fatal(err)
+	}
+	if c := a.Column(); c != 3 {
+		t.Fatalf("column %d after Size, expected 3", c)
+	}
+}
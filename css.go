@@ -0,0 +1,66 @@
+package ansi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var cssNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// xtermColors are the default colors of the 16 color palette
+var xtermColors = []Color{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// xterm256 returns the usual color of index n in the 256 color
+// palette: 16 basic colors, a 6x6x6 cube, then 24 grays
+func xterm256(n int) Color {
+	switch {
+	case n < 16:
+		return xtermColors[n]
+	case n < 232:
+		n -= 16
+		level := func(i int) uint8 {
+			if i == 0 {
+				return 0
+			}
+			return uint8(55 + i*40)
+		}
+		return Color{level(n / 36), level(n / 6 % 6), level(n % 6)}
+	}
+	g := uint8(8 + (n-232)*10)
+	return Color{g, g, g}
+}
+
+// CSS returns the attribute's color as a CSS color, a name for the
+// basic colors and #rrggbb otherwise. ok is false for attributes
+// which are not a single foreground or background color.
+func (a Attribute) CSS() (css string, ok bool) {
+	fields := strings.Split(string(a), ";")
+	p := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || n > 255 {
+			return "", false
+		}
+		p[i] = n
+	}
+	var c Color
+	switch {
+	case len(p) == 1 && (p[0] >= 30 && p[0] <= 37 || p[0] >= 40 && p[0] <= 47):
+		return cssNames[p[0]%10], true
+	case len(p) == 1 && (p[0] >= 90 && p[0] <= 97 || p[0] >= 100 && p[0] <= 107):
+		c = xtermColors[8+p[0]%10]
+	case len(p) == 3 && (p[0] == 38 || p[0] == 48) && p[1] == 5:
+		c = xterm256(p[2])
+	case len(p) == 5 && (p[0] == 38 || p[0] == 48) && p[1] == 2:
+		c = Color{uint8(p[2]), uint8(p[3]), uint8(p[4])}
+	default:
+		return "", false
+	}
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B), true
+}
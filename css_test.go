@@ -0,0 +1,26 @@
+package ansi
+
+import "testing"
+
+func TestCSS(t *testing.T) {
+	for _, tc := range []struct {
+		attr Attribute
+		css  string
+		ok   bool
+	}{
+		{Red, "red", true},
+		{BlueBG, "blue", true},
+		{"92", "#00ff00", true},
+		{"38;5;196", "#ff0000", true},
+		{"48;5;244", "#808080", true},
+		{"38;2;1;2;255", "#0102ff", true},
+		{Bright, "", false},
+		{Red.Dim(), "", false},
+		{"38;2;256;0;0", "", false},
+	} {
+		css, ok := tc.attr.CSS()
+		if css != tc.css || ok != tc.ok {
+			t.Errorf("%q.CSS() = %q, %v, expected %q, %v", tc.attr, css, ok, tc.css, tc.ok)
+		}
+	}
+}
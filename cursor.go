@@ -0,0 +1,68 @@
+package ansi
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CursorShape is a cursor style, as set by DECSCUSR
+type CursorShape int
+
+const (
+	DefaultCursor CursorShape = iota
+	BlinkingBlock
+	SteadyBlock
+	BlinkingUnderline
+	SteadyUnderline
+	BlinkingBar
+	SteadyBar
+)
+
+// Set Cursor Shape	<ESC>[{shape} q
+func SetCursorShape(s CursorShape) []byte {
+	b := append([]byte{Esc, '['}, strconv.Itoa(int(s))...)
+	return append(b, ' ', 'q')
+}
+
+func (a *Ansi) SetCursorShape(s CursorShape) {
+	a.Write(SetCursorShape(s))
+}
+
+// ErrRejected is returned by queries the terminal answered
+// by saying it does not know what was asked for
+var ErrRejected = errors.New("Query rejected by terminal")
+
+// Query Setting		<ESC>P$q{name}<ESC>\
+// Report Setting		<ESC>P1$r{value}{name}<ESC>\
+// Report Setting Rejected	<ESC>P0$r<ESC>\
+func QuerySetting(name string) []byte {
+	b := append([]byte{Esc, 'P', '$', 'q'}, name...)
+	return append(b, Esc, '\\')
+}
+
+// QueryCursorStyle asks the terminal (DECRQSS) for the current
+// cursor shape, so it can be restored with SetCursorShape
+func (a *Ansi) QueryCursorStyle(timeout time.Duration) (CursorShape, error) {
+	r, err := a.query(QuerySetting(" q"), func(r *Report) bool {
+		return r.Type == Setting && (r.Text == "" || strings.HasSuffix(r.Text, " q"))
+	}, timeout)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(r.Text, " q"))
+	if err != nil {
+		return 0, ErrRejected
+	}
+	return CursorShape(n), nil
+}
+
+// parseSetting is the report of a DECRQSS answer
+func (a *Ansi) parseSetting(valid bool, body string) *Report {
+	r := &Report{Type: Setting}
+	if valid {
+		r.Text = body
+	}
+	return r
+}
@@ -0,0 +1,37 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetCursorShape(t *testing.T) {
+	if got := string(SetCursorShape(SteadyBar)); got != "\x1b[6 q" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestQueryCursorStyle(t *testing.T) {
+	for _, tc := range []struct {
+		reply string
+		shape CursorShape
+		err   error
+	}{
+		{"\x1bP1$r4 q\x1b\\", SteadyUnderline, nil},
+		{"\x1bP0$r\x1b\\", 0, ErrRejected},
+	} {
+		f := newFakeTerm()
+		f.reply = func(q string) string {
+			if q != "\x1bP$q q\x1b\\" {
+				t.Errorf("unexpected query %q", q)
+			}
+			return tc.reply
+		}
+		a := Wrap(f)
+		shape, err := a.QueryCursorStyle(time.Second)
+		a.Close()
+		if shape != tc.shape || err != tc.err {
+			t.Errorf("%q: got %v %v, expected %v %v", tc.reply, shape, err, tc.shape, tc.err)
+		}
+	}
+}
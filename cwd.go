@@ -0,0 +1,25 @@
+package ansi
+
+import (
+	"net/url"
+	"os"
+)
+
+// Set Working Directory	<ESC>]7;file://{host}{path}<ESC>\
+func CWD(host, path string) []byte {
+	b := append([]byte{Esc, ']', '7', ';'}, fileURL(host, path)...)
+	return append(b, Esc, '\\')
+}
+
+// fileURL escapes path into a file:// URL on host
+func fileURL(host, path string) string {
+	u := url.URL{Scheme: "file", Host: host, Path: path}
+	return u.String()
+}
+
+// SetCWD tells the terminal the current working directory, which
+// it may use for new tabs. The host is this machine's hostname.
+func (a *Ansi) SetCWD(path string) {
+	host, _ := os.Hostname()
+	a.Write(CWD(host, path))
+}
@@ -0,0 +1,18 @@
+package ansi
+
+import "testing"
+
+func TestCWD(t *testing.T) {
+	for _, tc := range []struct {
+		path, url string
+	}{
+		{"/home/me", "file://box/home/me"},
+		{"/my docs/50%", "file://box/my%20docs/50%25"},
+		{"/a?b#c/ü", "file://box/a%3Fb%23c/%C3%BC"},
+	} {
+		want := "\x1b]7;" + tc.url + "\x1b\\"
+		if got := string(CWD("box", tc.path)); got != want {
+			t.Errorf("CWD(%q) = %q, expected %q", tc.path, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package ansi
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ModeState is the status of a mode, one
+// of ModeUnknown, ModeSet, ModeReset and so on
+type ModeState int
+
+// Set Private Mode	<ESC>[?{mode}h
+// Reset Private Mode	<ESC>[?{mode}l
+func SetMode(mode int, on bool) []byte {
+	b := strconv.AppendInt([]byte{Esc, '[', '?'}, int64(mode), 10)
+	if on {
+		return append(b, 'h')
+	}
+	return append(b, 'l')
+}
+
+// SetMode turns the DEC private mode on or off, for
+// the modes without a method of their own
+func (a *Ansi) SetMode(mode int, on bool) {
+	a.Write(SetMode(mode, on))
+}
+
+// Query Private Mode	<ESC>[?{mode}$p
+// Report Mode		<ESC>[?{mode};{status}$y
+func QueryMode(mode int) []byte {
+	b := strconv.AppendInt([]byte{Esc, '[', '?'}, int64(mode), 10)
+	return append(b, '$', 'p')
+}
+
+// QueryMode asks the terminal for the state of the DEC private
+// mode, waiting for its report until ctx is done. Terminals
+// which do not know the mode answer ModeUnknown.
+func (a *Ansi) QueryMode(ctx context.Context, mode int) (ModeState, error) {
+	r, err := a.queryContext(ctx, QueryMode(mode), func(r *Report) bool {
+		return r.Type == ModeStatus && r.Code == mode && strings.HasPrefix(r.Text, "?")
+	})
+	if err != nil {
+		return ModeUnknown, err
+	}
+	return ModeState(r.Params[1]), nil
+}
@@ -0,0 +1,47 @@
+package ansi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestQueryMode(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		switch q {
+		case "\x1b[?7$p":
+			//an ANSI mode report of the same number first
+			return "\x1b[7;2$y\x1b[?7;1$y"
+		case "\x1b[?2026$p":
+			return "\x1b[?2026;0$y"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if s, err := a.QueryMode(ctx, 7); err != nil || s != ModeSet {
+		t.Fatalf("got %v, %v", s, err)
+	}
+	if s, err := a.QueryMode(ctx, 2026); err != nil || s != ModeUnknown {
+		t.Fatalf("got %v, %v", s, err)
+	}
+	short, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	if _, err := a.QueryMode(short, 1004); err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to pass, got %v", err)
+	}
+}
+
+func TestSetMode(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.SetMode(6, true)
+	a.SetMode(1004, false)
+	if got, want := f.written(), "\x1b[?6h\x1b[?1004l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,179 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// TokenType is the kind of a Token
+type TokenType int
+
+const (
+	//TextToken is a run of plain text
+	TextToken TokenType = iota
+	//CSIToken is a control sequence <ESC>[...
+	CSIToken
+	//OSCToken is an operating system command <ESC>]...
+	OSCToken
+	//DCSToken is a device control string <ESC>P...
+	DCSToken
+	//StringToken is one of the other strings:
+	//SOS <ESC>X, PM <ESC>^ or APC <ESC>_
+	StringToken
+	//EscToken is any other escape sequence
+	EscToken
+)
+
+// Token is a piece of a terminal stream
+type Token struct {
+	Type TokenType
+	//Raw holds the bytes of the token as read
+	Raw []byte
+	//Prefix holds the private marker (one of ?<=>)
+	//of a CSI or DCS token, 0 when there is none
+	Prefix byte
+	//Params holds the numeric parameters of a CSI or DCS
+	//token, empty ones are 0 and sub-parameters (after
+	//a colon) are left out. For an OSC token it holds
+	//the command number.
+	Params []int
+	//Intermediates holds the bytes between the
+	//parameters and the final byte
+	Intermediates string
+	//Final holds the final byte of a CSI, DCS or escape
+	//sequence, 0 when it was cut off by the end of the stream
+	Final byte
+	//Text holds a text run, the text of an OSC token after
+	//the command number, or the data of a DCS or other string
+	Text string
+}
+
+// maxSequence is the longest sequence a Decoder waits for the end
+// of, longer ones are returned cut off
+const maxSequence = 1 << 16
+
+// Decoder splits a terminal stream into tokens
+type Decoder struct {
+	r          io.Reader
+	buf, chunk []byte
+	err        error
+}
+
+// NewDecoder returns a Decoder reading r
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, chunk: make([]byte, 4096)}
+}
+
+// Next returns the next token. Text is returned as soon as it is
+// read, escape sequences once they are complete. A sequence cut off
+// by the end of the stream is returned with a Final of 0. At the end
+// of the stream the error from the reader, typically io.EOF, is
+// returned.
+func (d *Decoder) Next() (Token, error) {
+	for {
+		if len(d.buf) > 0 {
+			if d.buf[0] != Esc {
+				n := bytes.IndexByte(d.buf, Esc)
+				if n < 0 {
+					n = len(d.buf)
+				}
+				raw := d.take(n)
+				return Token{Type: TextToken, Raw: raw, Text: string(raw)}, nil
+			}
+			n, ok := sequenceLen(string(d.buf))
+			if ok || d.err != nil || len(d.buf) > maxSequence {
+				return parseToken(d.take(n)), nil
+			}
+		}
+		if d.err != nil {
+			return Token{}, d.err
+		}
+		n, err := d.r.Read(d.chunk)
+		d.buf = append(d.buf, d.chunk[:n]...)
+		d.err = err
+	}
+}
+
+// take removes and returns the first n buffered bytes
+func (d *Decoder) take(n int) []byte {
+	b := append([]byte(nil), d.buf[:n]...)
+	d.buf = d.buf[n:]
+	return b
+}
+
+// parseToken parses the escape sequence raw
+func parseToken(raw []byte) Token {
+	t := Token{Type: EscToken, Raw: raw}
+	s := string(raw)
+	if len(s) < 2 {
+		return t
+	}
+	switch s[1] {
+	case '[':
+		t.Type = CSIToken
+		t.Prefix, t.Params, t.Intermediates, t.Final, _ = parseControl(s[2:])
+	case ']':
+		t.Type = OSCToken
+		body := stringBody(s[2:])
+		if i := strings.IndexByte(body, ';'); i >= 0 {
+			if n, err := strconv.Atoi(body[:i]); err == nil {
+				t.Params = []int{n}
+				body = body[i+1:]
+			}
+		}
+		t.Text = body
+	case 'P':
+		t.Type = DCSToken
+		t.Prefix, t.Params, t.Intermediates, t.Final, t.Text = parseControl(stringBody(s[2:]))
+	case 'X', '^', '_':
+		t.Type = StringToken
+		t.Text = stringBody(s[2:])
+	default:
+		if c := s[len(s)-1]; c >= 0x30 && c <= 0x7e {
+			t.Intermediates, t.Final = s[1:len(s)-1], c
+		} else {
+			t.Intermediates = s[1:]
+		}
+	}
+	return t
+}
+
+// parseControl parses the {prefix}{params}{intermediates}{final}
+// start of body, rest is whatever follows the final byte
+func parseControl(body string) (prefix byte, p []int, inter string, final byte, rest string) {
+	if len(body) > 0 && strings.IndexByte("?<=>", body[0]) >= 0 {
+		prefix, body = body[0], body[1:]
+	}
+	i := 0
+	for i < len(body) && (body[i] >= '0' && body[i] <= '9' || body[i] == ';' || body[i] == ':') {
+		i++
+	}
+	if i > 0 {
+		p = params(body[:i])
+		//drop sub-parameters
+		for j, f := range strings.Split(body[:i], ";") {
+			if k := strings.IndexByte(f, ':'); k >= 0 {
+				p[j], _ = strconv.Atoi(f[:k])
+			}
+		}
+	}
+	j := i
+	for j < len(body) && body[j] >= 0x20 && body[j] <= 0x2f {
+		j++
+	}
+	inter = body[i:j]
+	if j < len(body) && body[j] >= 0x40 && body[j] <= 0x7e {
+		return prefix, p, inter, body[j], body[j+1:]
+	}
+	return prefix, p, inter, 0, body[j:]
+}
+
+// stringBody trims the terminator (BEL or ST) off a string
+func stringBody(s string) string {
+	if strings.HasSuffix(s, "\x1b\\") {
+		return s[:len(s)-2]
+	}
+	return strings.TrimSuffix(s, "\x07")
+}
@@ -0,0 +1,67 @@
+package ansi
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder(t *testing.T) {
+	stream := "ab\x1b[1;31mcd\x1b[?25l\x1b]8;;http://x\x1b\\\x1bP1$r0m\x1b\\\x1b(0\x1b[4:3m\x1b_hi\x1b\\\x1b[2"
+	//one byte at a time, so every sequence is split across reads
+	d := NewDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+	var got []Token
+	for {
+		tok, err := d.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		tok.Raw = nil
+		got = append(got, tok)
+	}
+	want := []Token{
+		{Type: TextToken, Text: "a"},
+		{Type: TextToken, Text: "b"},
+		{Type: CSIToken, Params: []int{1, 31}, Final: 'm'},
+		{Type: TextToken, Text: "c"},
+		{Type: TextToken, Text: "d"},
+		{Type: CSIToken, Prefix: '?', Params: []int{25}, Final: 'l'},
+		{Type: OSCToken, Params: []int{8}, Text: ";http://x"},
+		{Type: DCSToken, Params: []int{1}, Intermediates: "$", Final: 'r', Text: "0m"},
+		{Type: EscToken, Intermediates: "(", Final: '0'},
+		{Type: CSIToken, Params: []int{4}, Final: 'm'},
+		{Type: StringToken, Text: "hi"},
+		{Type: CSIToken, Params: []int{2}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, expected %+v", got, want)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("token %d: got %+v, expected %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecoderRaw(t *testing.T) {
+	stream := "x\x1b[Hyz\x1b]2;title\x07"
+	d := NewDecoder(strings.NewReader(stream))
+	var raw string
+	for {
+		tok, err := d.Next()
+		if err != nil {
+			break
+		}
+		raw += string(tok.Raw)
+		if tok.Type == CSIToken && (tok.Final != 'H' || tok.Params != nil) {
+			t.Errorf("unexpected %+v", tok)
+		}
+	}
+	if raw != stream {
+		t.Fatalf("expected the tokens to cover the stream, got %q", raw)
+	}
+}
@@ -0,0 +1,109 @@
+package ansi
+
+import "strings"
+
+// ColorDepth guesses the number of bits of color the terminal
+// supports from the environment: 24 when COLORTERM says truecolor,
+// 8 for a 256 color TERM, 1 when TERM is empty or dumb (or NO_COLOR
+// is set) and otherwise 4, the basic 16 colors. CLICOLOR_FORCE
+// gives at least 4, unless NO_COLOR is set.
+func ColorDepth() int {
+	return colorDepth(getenv, getenv("TERM"))
+}
+
+func colorDepth(getenv func(string) string, term string) int {
+	if getenv("NO_COLOR") != "" {
+		return 1
+	}
+	depth := 4
+	term = strings.ToLower(term)
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return 24
+	}
+	switch {
+	case term == "" || term == "dumb":
+		depth = 1
+	case strings.HasSuffix(term, "-direct"), strings.Contains(term, "truecolor"):
+		return 24
+	case strings.Contains(term, "256color"):
+		return 8
+	}
+	if force := getenv("CLICOLOR_FORCE"); force != "" && force != "0" {
+		return 4
+	}
+	return depth
+}
+
+// Profile is the range of colors a terminal supports
+type Profile int
+
+const (
+	//NoColor has attributes like bold, but no colors
+	NoColor Profile = iota
+	//ANSI16 has the 16 basic colors
+	ANSI16
+	//ANSI256 has 256 palette colors
+	ANSI256
+	//TrueColor has 24-bit RGB colors
+	TrueColor
+)
+
+// Depth returns the bits of color of the profile, as
+// used by ColorDepth and DowngradeWriter
+func (p Profile) Depth() int {
+	switch p {
+	case NoColor:
+		return 1
+	case ANSI16:
+		return 4
+	case ANSI256:
+		return 8
+	}
+	return 24
+}
+
+// DetectProfile is ColorDepth as a Profile, for the environment env
+// (in the KEY=value form of os.Environ) and the terminal termName,
+// TERM from env when termName is ""
+func DetectProfile(env []string, termName string) Profile {
+	vars := map[string]string{}
+	for _, kv := range env {
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			vars[kv[:i]] = kv[i+1:]
+		}
+	}
+	if termName == "" {
+		termName = vars["TERM"]
+	}
+	switch colorDepth(func(k string) string { return vars[k] }, termName) {
+	case 1:
+		return NoColor
+	case 4:
+		return ANSI16
+	case 8:
+		return ANSI256
+	}
+	return TrueColor
+}
+
+// ColorProfile is the profile Set (and so WrapString, Style and
+// the color methods) converts colors to, like DowngradeWriter.
+// It is TrueColor, so colors are left as they are, until set, for
+// example to DetectProfile(os.Environ(), ""). The []byte vars
+// (like RedBytes) are built before it can be set, so are kept.
+var ColorProfile = TrueColor
+
+// downsample converts the colors of attrs to suit p
+func (p Profile) downsample(attrs []Attribute) []Attribute {
+	d := &Downgrader{depth: p.Depth()}
+	out := make([]Attribute, 0, len(attrs))
+	for _, a := range attrs {
+		for _, attr := range splitSGR(string(a)) {
+			if attr, ok := d.attribute(attr); ok {
+				out = append(out, attr)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,71 @@
+package ansi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestColorDepth(t *testing.T) {
+	defer func() { getenv = os.Getenv }()
+	for _, tc := range []struct {
+		term, colorterm, nocolor string
+		depth                    int
+	}{
+		{"", "", "", 1},
+		{"dumb", "", "", 1},
+		{"xterm", "", "", 4},
+		{"xterm-256color", "", "", 8},
+		{"screen-256color", "", "", 8},
+		{"xterm-256color", "truecolor", "", 24},
+		{"xterm", "24bit", "", 24},
+		{"xterm-direct", "", "", 24},
+		{"xterm-256color", "truecolor", "1", 1},
+	} {
+		env := map[string]string{"TERM": tc.term, "COLORTERM": tc.colorterm, "NO_COLOR": tc.nocolor}
+		getenv = func(k string) string { return env[k] }
+		if got := ColorDepth(); got != tc.depth {
+			t.Errorf("TERM=%q COLORTERM=%q NO_COLOR=%q: got %d, expected %d", tc.term, tc.colorterm, tc.nocolor, got, tc.depth)
+		}
+	}
+}
+
+func TestDetectProfile(t *testing.T) {
+	for _, tc := range []struct {
+		env  []string
+		term string
+		want Profile
+	}{
+		{nil, "", NoColor},
+		{nil, "xterm", ANSI16},
+		{[]string{"TERM=xterm-256color"}, "", ANSI256},
+		{[]string{"TERM=xterm-256color"}, "vt100", ANSI16},
+		{[]string{"COLORTERM=truecolor"}, "xterm", TrueColor},
+		{[]string{"CLICOLOR_FORCE=1"}, "dumb", ANSI16},
+		{[]string{"CLICOLOR_FORCE=0"}, "dumb", NoColor},
+		{[]string{"CLICOLOR_FORCE=1", "NO_COLOR=1"}, "xterm", NoColor},
+	} {
+		if got := DetectProfile(tc.env, tc.term); got != tc.want {
+			t.Errorf("%q %q: got %d, expected %d", tc.env, tc.term, got, tc.want)
+		}
+	}
+}
+
+func TestColorProfile(t *testing.T) {
+	defer func() { ColorProfile = TrueColor }()
+	rgb := RGB(255, 0, 0)
+	ColorProfile = ANSI256
+	if got := string(Set(Bright, rgb)); got != "\x1b[1;38;5;196m" {
+		t.Errorf("got %q", got)
+	}
+	ColorProfile = ANSI16
+	if got := NewStyle().FG(rgb).BG(Color256(4)).Sprint("x"); got != "\x1b[91;44mx\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+	ColorProfile = NoColor
+	if got := string(Set(Red)); got != "" {
+		t.Errorf("expected no sequence, got %q", got)
+	}
+	if got := WrapString("x", Bright+";"+Red); got != "\x1b[1mx\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+}
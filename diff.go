@@ -0,0 +1,51 @@
+package ansi
+
+import "strings"
+
+// RenderDiff renders a line diff turning oldText into newText.
+// Deleted lines are red and start with "-", added lines are
+// green and start with "+", common lines start with a space.
+func RenderDiff(oldText, newText string) []byte {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+	//lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var out []byte
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			out = append(out, " "+a[i]+"\n"...)
+			i, j = i+1, j+1
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, Red.String("-"+a[i])+"\n"...)
+			i++
+		default:
+			out = append(out, Green.String("+"+b[j])+"\n"...)
+			j++
+		}
+	}
+	return out
+}
+
+// splitLines splits s into lines, without a trailing empty line
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,22 @@
+package ansi
+
+import "testing"
+
+func TestRenderDiff(t *testing.T) {
+	del := func(s string) string { return Red.String("-"+s) + "\n" }
+	add := func(s string) string { return Green.String("+"+s) + "\n" }
+	for _, tc := range []struct {
+		old, new, out string
+	}{
+		{"", "", ""},
+		{"a\nb\n", "a\nb\n", " a\n b\n"},
+		{"a\nb\nc", "a\nc", " a\n" + del("b") + " c\n"},
+		{"a", "a\nb", " a\n" + add("b")},
+		{"a\nb", "a\nc", " a\n" + del("b") + add("c")},
+		{"x", "", del("x")},
+	} {
+		if got := string(RenderDiff(tc.old, tc.new)); got != tc.out {
+			t.Errorf("RenderDiff(%q, %q) = %q, expected %q", tc.old, tc.new, got, tc.out)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package ansi
+
+import (
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DowngradeWriter returns a writer which rewrites the colors written
+// through it to suit a terminal of depth bits of color (see
+// ColorDepth). At 8, 24-bit colors become the nearest of the 256
+// color palette. At 4, both become the nearest basic color, and
+// underline colors are dropped, and at 1 all colors are dropped.
+// A depth of 24 or more passes everything on as it is.
+func DowngradeWriter(w io.Writer, depth int) *Downgrader {
+	return &Downgrader{w: w, depth: depth}
+}
+
+// Downgrader is the writer returned by DowngradeWriter
+type Downgrader struct {
+	w       io.Writer
+	depth   int
+	partial []byte
+}
+
+// Write p, holding back a sequence split at the end
+// of it, since it cannot be rewritten until complete
+func (d *Downgrader) Write(p []byte) (int, error) {
+	if d.depth >= 24 {
+		return d.w.Write(p)
+	}
+	b := append(d.partial, p...)
+	d.partial = nil
+	if i := incomplete(b); i >= 0 {
+		d.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	var out []byte
+	s := string(b)
+	for i := 0; i < len(s); {
+		if params, n := sgrParams(s[i:]); n > 0 {
+			var attrs []Attribute
+			for _, attr := range splitSGR(params) {
+				if a, ok := d.attribute(attr); ok {
+					attrs = append(attrs, a)
+				}
+			}
+			//a sequence left empty would be a reset
+			if len(attrs) > 0 {
+				out = AppendSet(out, attrs...)
+			}
+			i += n
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] != Esc {
+			j++
+		}
+		out = append(out, s[i:j]...)
+		i = j
+	}
+	if len(out) > 0 {
+		if _, err := d.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush writes out the sequence held back by Write as it is,
+// for when nothing more is to be written
+func (d *Downgrader) Flush() error {
+	if len(d.partial) == 0 {
+		return nil
+	}
+	p := d.partial
+	d.partial = nil
+	_, err := d.w.Write(p)
+	return err
+}
+
+// attribute downgrades attr, ok is false when it is dropped
+func (d *Downgrader) attribute(attr string) (Attribute, bool) {
+	sep := ";"
+	if strings.Contains(attr, ":") {
+		//the colon form, 38:5:{n} or 38:2:{COLORSPACE}:{r}:{g}:{b},
+		//some terminals leave out the color space
+		sep = ":"
+	}
+	f := strings.Split(attr, sep)
+	if sep == ":" && len(f) == 6 && f[1] == "2" {
+		f = append(f[:2], f[3:]...)
+	}
+	p := make([]int, len(f))
+	for i := range f {
+		n, err := strconv.Atoi(f[i])
+		if err != nil || n < 0 || n > 255 {
+			return Attribute(attr), true
+		}
+		p[i] = n
+	}
+	bg := p[0] == 48 || p[0] >= 40 && p[0] <= 47 || p[0] >= 100 && p[0] <= 107
+	extended := p[0] == 38 || p[0] == 48 || p[0] == 58
+	var c Color
+	switch {
+	case len(p) == 5 && extended && p[1] == 2:
+		c = Color{uint8(p[2]), uint8(p[3]), uint8(p[4])}
+		if d.depth >= 8 {
+			return Attribute(f[0] + sep + "5" + sep + strconv.Itoa(nearestColor(c, 16, 256))), true
+		}
+	case len(p) == 3 && extended && p[1] == 5:
+		if d.depth >= 8 {
+			return Attribute(attr), true
+		}
+		c = xterm256(p[2])
+	case len(p) == 1 && sgrSlot(attr) != "" && p[0] != 39 && p[0] != 49:
+		//basic colors are kept, unless dropping colors
+		if d.depth > 1 {
+			return Attribute(attr), true
+		}
+	default:
+		return Attribute(attr), true
+	}
+	//there are no basic underline colors
+	if d.depth <= 1 || p[0] == 58 {
+		return "", false
+	}
+	n := nearestColor(c, 0, 16)
+	code := 30 + n
+	if n >= 8 {
+		code = 90 + n - 8
+	}
+	if bg {
+		code += 10
+	}
+	return Attribute(strconv.Itoa(code)), true
+}
+
+// nearestColor returns the index in [from,to) of the
+// 256 color palette which is closest to c
+func nearestColor(c Color, from, to int) int {
+	best, min := from, -1
+	for i := from; i < to; i++ {
+		p := xterm256(i)
+		dr, dg, db := int(c.R)-int(p.R), int(c.G)-int(p.G), int(c.B)-int(p.B)
+		if d := dr*dr + dg*dg + db*db; min < 0 || d < min {
+			best, min = i, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,70 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDowngradeWriter(t *testing.T) {
+	stream := string(Set(Bright, FGRGB(255, 0, 0))) + "red" +
+		string(Set(BGRGB(0, 0, 120), FG256(46))) + "x" + string(Set(Green)) + "y" + string(Set(Reset))
+	for _, tc := range []struct {
+		depth int
+		out   string
+	}{
+		{24, stream},
+		{8, "\x1b[1;38;5;196mred\x1b[48;5;18;38;5;46mx\x1b[32my\x1b[0m"},
+		{4, "\x1b[1;91mred\x1b[44;92mx\x1b[32my\x1b[0m"},
+		{1, "\x1b[1mredxy\x1b[0m"},
+	} {
+		var b bytes.Buffer
+		w := DowngradeWriter(&b, tc.depth)
+		//split mid sequence, it is still rewritten whole
+		for _, p := range []string{stream[:6], stream[6:]} {
+			if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+				t.Fatalf("unexpected %d %v", n, err)
+			}
+		}
+		if got := b.String(); got != tc.out {
+			t.Errorf("depth %d: got %q, expected %q", tc.depth, got, tc.out)
+		}
+	}
+}
+
+func TestDowngradeColonForm(t *testing.T) {
+	for _, tc := range []struct {
+		in    string
+		depth int
+		out   string
+	}{
+		{"\x1b[38:2::255:0:0mx", 8, "\x1b[38:5:196mx"},
+		{"\x1b[48:2:0:0:120mx", 8, "\x1b[48:5:18mx"},
+		{"\x1b[58:2::255:0:0mx", 8, "\x1b[58:5:196mx"},
+		{"\x1b[58;2;255;0;0mx", 8, "\x1b[58;5;196mx"},
+		{"\x1b[38:2::255:0:0mx", 4, "\x1b[91mx"},
+		{"\x1b[48:5:46mx", 4, "\x1b[102mx"},
+		{"\x1b[4:3;58:5:196mx", 4, "\x1b[4:3mx"},
+		{"\x1b[1;38:2::255:0:0mx", 1, "\x1b[1mx"},
+	} {
+		var b bytes.Buffer
+		DowngradeWriter(&b, tc.depth).Write([]byte(tc.in))
+		if got := b.String(); got != tc.out {
+			t.Errorf("%q at depth %d: got %q, expected %q", tc.in, tc.depth, got, tc.out)
+		}
+	}
+}
+
+func TestDowngradeFlush(t *testing.T) {
+	var b bytes.Buffer
+	w := DowngradeWriter(&b, 8)
+	w.Write([]byte("x\x1b[38;2"))
+	if got := b.String(); got != "x" {
+		t.Fatalf("expected the partial sequence held, got %q", got)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != "x\x1b[38;2" {
+		t.Fatalf("expected the partial sequence on Flush, got %q", got)
+	}
+}
@@ -0,0 +1,74 @@
+package ansi
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// EchoReports writes a line describing each parsed report to w,
+// like "POSITION row=5 col=7", for debugging terminal handshakes.
+// A nil w stops the echo.
+func (a *Ansi) EchoReports(w io.Writer) {
+	a.mu.Lock()
+	a.echo = w
+	a.mu.Unlock()
+}
+
+var reportNames = map[ReportType]string{
+	Code:          "CODE",
+	OK:            "OK",
+	Failure:       "FAILURE",
+	Position:      "POSITION",
+	Termcap:       "TERMCAP",
+	Window:        "WINDOW",
+	Custom:        "CUSTOM",
+	Paste:         "PASTE",
+	OSC:           "OSC",
+	PrinterStatus: "PRINTER",
+	Setting:       "SETTING",
+	Mouse:         "MOUSE",
+	MacroSpace:    "MACROSPACE",
+	Checksum:      "CHECKSUM",
+	SecondaryCode: "SECONDARY",
+	ModeStatus:    "MODE",
+	Focus:         "FOCUS",
+	Blur:          "BLUR",
+	Invalid:       "INVALID",
+}
+
+// describe formats r for EchoReports
+func describe(r *Report) string {
+	name, ok := reportNames[r.Type]
+	if !ok {
+		name = fmt.Sprintf("TYPE%d", r.Type)
+	}
+	switch r.Type {
+	case Code, PrinterStatus, MacroSpace:
+		return fmt.Sprintf("%s code=%d", name, r.Code)
+	case Position:
+		return fmt.Sprintf("%s row=%d col=%d", name, r.Pos.Row, r.Pos.Col)
+	case Termcap:
+		if r.Caps == nil {
+			return name + " rejected"
+		}
+		caps := make([]string, 0, len(r.Caps))
+		for k, v := range r.Caps {
+			caps = append(caps, fmt.Sprintf("%s=%q", k, v))
+		}
+		sort.Strings(caps)
+		return name + " " + strings.Join(caps, " ")
+	case Mouse:
+		return fmt.Sprintf("%s button=%d row=%d col=%d press=%v", name, r.Code, r.Pos.Row, r.Pos.Col, r.Press)
+	case Window, SecondaryCode, ModeStatus:
+		return fmt.Sprintf("%s params=%v", name, r.Params)
+	case Custom:
+		return fmt.Sprintf("%s final=%c params=%v", name, r.Final, r.Params)
+	case Paste, Setting, Invalid:
+		return fmt.Sprintf("%s text=%q", name, r.Text)
+	case OSC, Checksum:
+		return fmt.Sprintf("%s code=%d text=%q", name, r.Code, r.Text)
+	}
+	return name
+}
@@ -0,0 +1,22 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEchoReports(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	var echo bytes.Buffer
+	a.EchoReports(&echo)
+	go f.send("\x1b[5;7R\x1b[0n\x1b[8;24;80t")
+	for i := 0; i < 3; i++ {
+		<-a.Reports
+	}
+	want := "POSITION row=5 col=7\nOK\nWINDOW params=[8 24 80]\n"
+	if got := echo.String(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
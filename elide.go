@@ -0,0 +1,56 @@
+package ansi
+
+import "strings"
+
+const ellipsis = "…"
+
+// ElidePath shortens path to fit maxWidth visible columns by
+// replacing middle components with an ellipsis, keeping the first
+// component and as many trailing ones as fit. When even the last
+// component is too wide it is cut from the left. Separators are
+// styled with sep, unless it is empty.
+func ElidePath(path string, maxWidth int, sep Attribute) []byte {
+	if maxWidth <= 0 {
+		return nil
+	}
+	parts := strings.Split(path, "/")
+	n := len(parts)
+	fits := func(c []string) bool {
+		return VisibleLength(strings.Join(c, "/")) <= maxWidth
+	}
+	if fits(parts) {
+		return joinPath(parts, sep)
+	}
+	for keep := n - 2; keep >= 1; keep-- {
+		c := append([]string{parts[0], ellipsis}, parts[n-keep:]...)
+		if fits(c) {
+			return joinPath(c, sep)
+		}
+	}
+	last := parts[n-1]
+	if n > 1 {
+		if c := []string{ellipsis, last}; fits(c) {
+			return joinPath(c, sep)
+		}
+	}
+	l := VisibleLength(last)
+	return []byte(ellipsis + VisibleSlice(last, l-(maxWidth-1), l))
+}
+
+// joinPath joins parts with separators styled with sep
+func joinPath(parts []string, sep Attribute) []byte {
+	var b []byte
+	for i, p := range parts {
+		if i > 0 {
+			if sep != "" {
+				b = AppendSet(b, sep)
+			}
+			b = append(b, '/')
+			if sep != "" {
+				b = AppendSet(b, Reset)
+			}
+		}
+		b = append(b, p...)
+	}
+	return b
+}
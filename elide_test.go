@@ -0,0 +1,42 @@
+package ansi
+
+import "testing"
+
+func TestElidePath(t *testing.T) {
+	path := "/home/user/projects/go/src/ansi"
+	for _, tc := range []struct {
+		width int
+		out   string
+	}{
+		{40, "/home/user/projects/go/src/ansi"},
+		{31, "/home/user/projects/go/src/ansi"},
+		{30, "/…/user/projects/go/src/ansi"},
+		{24, "/…/projects/go/src/ansi"},
+		{20, "/…/go/src/ansi"},
+		{12, "/…/src/ansi"},
+		{8, "/…/ansi"},
+		{6, "…/ansi"},
+		{4, "…nsi"},
+		{1, "…"},
+		{0, ""},
+	} {
+		got := string(ElidePath(path, tc.width, ""))
+		if got != tc.out {
+			t.Errorf("ElidePath(%d) = %q, expected %q", tc.width, got, tc.out)
+		}
+		if n := VisibleLength(got); n > tc.width {
+			t.Errorf("ElidePath(%d) is %d wide", tc.width, n)
+		}
+	}
+}
+
+func TestElidePathSep(t *testing.T) {
+	sep := string(Set(Blue)) + "/" + string(Set(Reset))
+	got := string(ElidePath("a/b/c/d", 5, Blue))
+	if want := "a" + sep + "…" + sep + "d"; got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+	if n := VisibleLength(got); n != 5 {
+		t.Errorf("expected 5 columns, got %d", n)
+	}
+}
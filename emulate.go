@@ -0,0 +1,244 @@
+package ansi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Write updates the screen with the ANSI text p as a terminal would:
+// text is written at the cursor, wrapping and scrolling at the edges,
+// and cursor moves, erases, scrolls and SGR sets are applied. Other
+// sequences are ignored. A sequence split between writes is applied
+// once complete. Write never fails.
+func (s *Screen) Write(p []byte) (int, error) {
+	b := append(s.partial, p...)
+	s.partial = nil
+	if i := incomplete(b); i >= 0 {
+		s.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	str := string(b)
+	for i := 0; i < len(str); {
+		if str[i] == Esc {
+			n, _ := sequenceLen(str[i:])
+			s.apply(str[i : i+n])
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(str[i:])
+		i += n
+		s.put(r)
+	}
+	return len(p), nil
+}
+
+// Cursor returns the row and column of the cursor
+func (s *Screen) Cursor() (row, col int) {
+	if s.col >= s.Cols {
+		return s.row, s.Cols - 1
+	}
+	return s.row, s.col
+}
+
+// String returns the text of the screen, a line per
+// row with the trailing spaces trimmed off
+func (s *Screen) String() string {
+	lines := make([]string, s.Rows)
+	for r := range lines {
+		var line strings.Builder
+		for c := 0; c < s.Cols; c++ {
+			line.WriteRune(s.Cell(r, c).Rune)
+		}
+		lines[r] = strings.TrimRight(line.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// put writes r at the cursor or applies it as a control character
+func (s *Screen) put(r rune) {
+	switch {
+	case r == '\r':
+		s.col = 0
+	case r == '\n', r == '\v', r == '\f':
+		s.lineFeed()
+	case r == '\b':
+		if s.col = s.clampCol(s.col) - 1; s.col < 0 {
+			s.col = 0
+		}
+	case r == '\t':
+		if s.col = (s.clampCol(s.col)/8 + 1) * 8; s.col >= s.Cols {
+			s.col = s.Cols - 1
+		}
+	case r < 0x20 || r >= 0x7f && r < 0xa0:
+	default:
+		if s.col >= s.Cols {
+			s.col = 0
+			s.lineFeed()
+		}
+		s.SetCell(s.row, s.col, Cell{Rune: r, Style: s.style()})
+		s.col++
+	}
+}
+
+// apply applies the escape sequence seq
+func (s *Screen) apply(seq string) {
+	t := parseToken([]byte(seq))
+	if t.Type == EscToken && t.Intermediates == "" {
+		switch t.Final {
+		case '7':
+			s.saved = [2]int{s.row, s.col}
+		case '8':
+			s.row, s.col = s.saved[0], s.saved[1]
+		case 'D':
+			s.lineFeed()
+		case 'E':
+			s.col = 0
+			s.lineFeed()
+		case 'M':
+			if s.row == 0 {
+				s.scroll(-1)
+			} else {
+				s.row--
+			}
+		case 'c':
+			*s = *NewScreen(s.Rows, s.Cols)
+		}
+		return
+	}
+	if t.Type != CSIToken || t.Prefix != 0 || t.Intermediates != "" {
+		return
+	}
+	arg := func(i int) int {
+		if i < len(t.Params) && t.Params[i] > 0 {
+			return t.Params[i]
+		}
+		return 1
+	}
+	mode := 0
+	if len(t.Params) > 0 {
+		mode = t.Params[0]
+	}
+	col := s.clampCol(s.col)
+	switch t.Final {
+	case 'A':
+		s.row -= arg(0)
+	case 'B':
+		s.row += arg(0)
+	case 'C':
+		s.col = col + arg(0)
+	case 'D':
+		s.col = col - arg(0)
+	case 'E':
+		s.row, s.col = s.row+arg(0), 0
+	case 'F':
+		s.row, s.col = s.row-arg(0), 0
+	case 'G':
+		s.col = arg(0) - 1
+	case 'd':
+		s.row = arg(0) - 1
+	case 'H', 'f':
+		s.row, s.col = arg(0)-1, arg(1)-1
+	case 'J':
+		switch mode {
+		case 0:
+			s.clear(s.row, col, s.row, s.Cols)
+			s.clear(s.row+1, 0, s.Rows, s.Cols)
+		case 1:
+			s.clear(0, 0, s.row-1, s.Cols)
+			s.clear(s.row, 0, s.row, col+1)
+		case 2, 3:
+			s.clear(0, 0, s.Rows, s.Cols)
+		}
+	case 'K':
+		switch mode {
+		case 0:
+			s.clear(s.row, col, s.row, s.Cols)
+		case 1:
+			s.clear(s.row, 0, s.row, col+1)
+		case 2:
+			s.clear(s.row, 0, s.row, s.Cols)
+		}
+	case 'S':
+		s.scroll(arg(0))
+	case 'T':
+		s.scroll(-arg(0))
+	case 's':
+		s.saved = [2]int{s.row, s.col}
+	case 'u':
+		s.row, s.col = s.saved[0], s.saved[1]
+	case 'm':
+		params, _ := sgrParams(seq)
+		for _, attr := range splitSGR(params) {
+			s.active = applyAttribute(s.active, Attribute(attr))
+		}
+	}
+	if s.row < 0 {
+		s.row = 0
+	} else if s.row >= s.Rows {
+		s.row = s.Rows - 1
+	}
+	if s.col < 0 {
+		s.col = 0
+	} else if s.col >= s.Cols {
+		s.col = s.Cols - 1
+	}
+}
+
+// clampCol returns col moved back from a pending wrap
+func (s *Screen) clampCol(col int) int {
+	if col >= s.Cols {
+		return s.Cols - 1
+	}
+	return col
+}
+
+// lineFeed moves the cursor down, scrolling at the bottom
+func (s *Screen) lineFeed() {
+	if s.row == s.Rows-1 {
+		s.scroll(1)
+	} else {
+		s.row++
+	}
+}
+
+// scroll moves the content up n rows, down when n is negative,
+// filling the rows left behind with blanks
+func (s *Screen) scroll(n int) {
+	if n >= s.Rows || -n >= s.Rows {
+		s.clear(0, 0, s.Rows, s.Cols)
+		return
+	}
+	if n > 0 {
+		copy(s.cells, s.cells[n*s.Cols:])
+		s.clear(s.Rows-n, 0, s.Rows, s.Cols)
+	} else if n < 0 {
+		copy(s.cells[-n*s.Cols:], s.cells)
+		s.clear(0, 0, -n-1, s.Cols)
+	}
+}
+
+// clear blanks the cells from row r1 column c1 up to (not
+// including) column c2 of row r2, rows in between whole
+func (s *Screen) clear(r1, c1, r2, c2 int) {
+	for r := r1; r <= r2 && r < s.Rows; r++ {
+		from, to := 0, s.Cols
+		if r == r1 {
+			from = c1
+		}
+		if r == r2 {
+			to = c2
+		}
+		for c := from; c < to; c++ {
+			s.SetCell(r, c, blank)
+		}
+	}
+}
+
+// style returns the SGR parameters active for written text
+func (s *Screen) style() string {
+	attrs := make([]string, len(s.active))
+	for i, a := range s.active {
+		attrs[i] = string(a)
+	}
+	return strings.Join(attrs, ";")
+}
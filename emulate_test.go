@@ -0,0 +1,49 @@
+package ansi
+
+import "testing"
+
+func TestScreenWrite(t *testing.T) {
+	s := NewScreen(3, 5)
+	s.Write([]byte("hello world"))
+	if got, want := s.String(), "hello\n worl\nd"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if r, c := s.Cursor(); r != 2 || c != 1 {
+		t.Fatalf("cursor at %d,%d", r, c)
+	}
+	//scrolls at the bottom
+	s.Write([]byte("\r\nnext"))
+	if got, want := s.String(), " worl\nd\nnext"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	s.Write([]byte("\x1b[1;2H\x1b[31"))
+	s.Write([]byte("mX\x1b[0mY\x1b[2;1H\x1b[K"))
+	if got, want := s.String(), " XYrl\n\nnext"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if c := s.Cell(0, 1); c != (Cell{Rune: 'X', Style: "31"}) {
+		t.Fatalf("got %+v", c)
+	}
+	if c := s.Cell(0, 2); c != (Cell{Rune: 'Y'}) {
+		t.Fatalf("got %+v", c)
+	}
+	s.Write([]byte("\x1b[3;3H\x1b[1J"))
+	if got, want := s.String(), "\n\n   t"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	s.Write([]byte("\x1b[2J\x1b[Habc\x1b[2D\x1b[Bz\x1b[T"))
+	if got, want := s.String(), "\nabc\n z"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestScreenWriteDiff(t *testing.T) {
+	prev := NewScreen(1, 4)
+	prev.Write([]byte("ab"))
+	next := NewScreen(1, 4)
+	next.Write([]byte("a\x1b[1mc"))
+	got := next.Diff(prev)
+	if len(got) != 1 || got[0].Col != 1 || got[0].New != (Cell{Rune: 'c', Style: "1"}) {
+		t.Fatalf("got %+v", got)
+	}
+}
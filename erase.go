@@ -0,0 +1,53 @@
+package ansi
+
+// EraseRegion is the part of the line or screen Erase clears
+type EraseRegion int
+
+const (
+	RegionEndOfLine EraseRegion = iota
+	RegionStartOfLine
+	RegionLine
+	RegionDown
+	RegionUp
+	RegionScreen
+	RegionScrollback
+)
+
+// Erase in Line		<ESC>[{0|1|2}K
+// Erase in Display		<ESC>[{0|1|2|3}J
+// Selective Erase in Line	<ESC>[?{0|1|2}K
+// Selective Erase in Display	<ESC>[?{0|1|2}J
+//
+// Erase returns the sequence clearing region, the selective forms
+// (DECSEL and DECSED) spare characters written within ProtectArea.
+// There is no selective form for the scrollback, it is always
+// cleared entirely.
+func Erase(region EraseRegion, selective bool) []byte {
+	final, n := byte('K'), byte('0')
+	switch region {
+	case RegionStartOfLine:
+		n = '1'
+	case RegionLine:
+		n = '2'
+	case RegionDown:
+		final = 'J'
+	case RegionUp:
+		final, n = 'J', '1'
+	case RegionScreen:
+		final, n = 'J', '2'
+	case RegionScrollback:
+		final, n, selective = 'J', '3', false
+	}
+	b := []byte{Esc, '['}
+	if selective {
+		b = append(b, '?')
+	}
+	if n != '0' {
+		b = append(b, n)
+	}
+	return append(b, final)
+}
+
+func (a *Ansi) Erase(region EraseRegion, selective bool) {
+	a.Write(Erase(region, selective))
+}
@@ -0,0 +1,31 @@
+package ansi
+
+import "testing"
+
+func TestEraseRegion(t *testing.T) {
+	for _, tc := range []struct {
+		region     EraseRegion
+		plain, dec string
+	}{
+		{RegionEndOfLine, "\x1b[K", "\x1b[?K"},
+		{RegionStartOfLine, "\x1b[1K", "\x1b[?1K"},
+		{RegionLine, "\x1b[2K", "\x1b[?2K"},
+		{RegionDown, "\x1b[J", "\x1b[?J"},
+		{RegionUp, "\x1b[1J", "\x1b[?1J"},
+		{RegionScreen, "\x1b[2J", "\x1b[?2J"},
+		{RegionScrollback, "\x1b[3J", "\x1b[3J"},
+	} {
+		if got := string(Erase(tc.region, false)); got != tc.plain {
+			t.Errorf("Erase(%d, false) = %q, expected %q", tc.region, got, tc.plain)
+		}
+		if got := string(Erase(tc.region, true)); got != tc.dec {
+			t.Errorf("Erase(%d, true) = %q, expected %q", tc.region, got, tc.dec)
+		}
+	}
+	//the same as the named sequences
+	if string(Erase(RegionEndOfLine, false)) != string(EraseEndLine) ||
+		string(Erase(RegionScreen, false)) != string(EraseScreen) ||
+		string(Erase(RegionDown, true)) != string(SelectiveEraseScreen) {
+		t.Error("expected Erase to match the named sequences")
+	}
+}
@@ -0,0 +1,33 @@
+package ansi
+
+// Event is a DataEvent or a ReportEvent
+type Event interface {
+	event()
+}
+
+// DataEvent holds plain data read from the stream
+type DataEvent struct {
+	Bytes []byte
+}
+
+// ReportEvent holds a report read from the stream
+type ReportEvent struct {
+	*Report
+}
+
+func (DataEvent) event()   {}
+func (ReportEvent) event() {}
+
+// ReadEvent returns the next piece of data or report, in the
+// order they appeared in the stream. Once called, reports are
+// delivered here instead of the Reports queue (though queries
+// still receive theirs) and Read will skip over them.
+func (a *Ansi) ReadEvent() (Event, error) {
+	a.mu.Lock()
+	a.inline = true
+	a.mu.Unlock()
+	if b := a.leftover(); len(b) > 0 {
+		return DataEvent{Bytes: b}, nil
+	}
+	return a.next()
+}
@@ -0,0 +1,38 @@
+package ansi
+
+import "testing"
+
+func TestReadEvent(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("x")
+	if e, err := a.ReadEvent(); err != nil || string(e.(DataEvent).Bytes) != "x" {
+		t.Fatalf("unexpected event %+v %v", e, err)
+	}
+	go f.send("ab\x1b[2;3Rcd\x1b[0n")
+	var got []string
+	for len(got) < 4 {
+		e, err := a.ReadEvent()
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch e := e.(type) {
+		case DataEvent:
+			got = append(got, string(e.Bytes))
+		case ReportEvent:
+			switch e.Type {
+			case Position:
+				got = append(got, "position")
+			case OK:
+				got = append(got, "ok")
+			}
+		}
+	}
+	want := []string{"ab", "position", "cd", "ok"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	}
+}
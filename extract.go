@@ -0,0 +1,83 @@
+package ansi
+
+import "unicode/utf8"
+
+// ExtractAttributes returns the attributes active at each rune of s
+// outside escape sequences, control characters aside, for testing
+// renderers and re-theming. A color replaces the previous color of
+// its slot, the off attributes (like 22 and 39) remove what they
+// turn off, and resets clear everything. Plain runes get nil.
+func ExtractAttributes(s string) [][]Attribute {
+	var out [][]Attribute
+	var active []Attribute
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			if params, m := sgrParams(s[i:]); m > 0 {
+				for _, attr := range splitSGR(params) {
+					active = applyAttribute(active, Attribute(attr))
+				}
+			}
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		if r < 0x20 || (r >= 0x7f && r < 0xa0) {
+			continue
+		}
+		var attrs []Attribute
+		if len(active) > 0 {
+			attrs = append(attrs, active...)
+		}
+		out = append(out, attrs)
+	}
+	return out
+}
+
+// offAttributes maps the attributes turning others off
+// to the ones they turn off
+var offAttributes = map[Attribute][]Attribute{
+	"22": {Bright, Dim},
+	"23": {Italic},
+	"24": {Underscore},
+	"25": {Blink},
+	"27": {Reverse},
+	"28": {Hidden},
+}
+
+// applyAttribute returns active updated by attr
+func applyAttribute(active []Attribute, attr Attribute) []Attribute {
+	if attr == Reset {
+		return nil
+	}
+	remove := func(match func(Attribute) bool) {
+		kept := active[:0]
+		for _, a := range active {
+			if !match(a) {
+				kept = append(kept, a)
+			}
+		}
+		active = kept
+	}
+	if off, ok := offAttributes[attr]; ok {
+		remove(func(a Attribute) bool {
+			for _, o := range off {
+				if a == o {
+					return true
+				}
+			}
+			return false
+		})
+		return active
+	}
+	if slot := sgrSlot(string(attr)); slot != "" {
+		remove(func(a Attribute) bool { return sgrSlot(string(a)) == slot })
+		if attr == Default || attr == DefaultBG {
+			return active
+		}
+	} else {
+		remove(func(a Attribute) bool { return a == attr })
+	}
+	return append(active, attr)
+}
@@ -0,0 +1,36 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractAttributes(t *testing.T) {
+	s := "a" + string(Set(Bright, Red)) + "bc" + string(Set(Blue)) + "d" +
+		string(Set("22")) + "e" + string(Set(Reset)) + "f\n" + string(Set(Green, Default)) + "g"
+	got := ExtractAttributes(s)
+	want := [][]Attribute{
+		nil,
+		{Bright, Red},
+		{Bright, Red},
+		{Bright, Blue},
+		{Blue},
+		nil,
+		nil,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestExtractAttributesExtended(t *testing.T) {
+	s := string(Set(FGRGB(1, 2, 3), BG256(4))) + "x" + string(Set(Red)) + "y"
+	got := ExtractAttributes(s)
+	want := [][]Attribute{
+		{FGRGB(1, 2, 3), BG256(4)},
+		{BG256(4), Red},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
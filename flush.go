@@ -0,0 +1,86 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+// newTicker is swapped out by tests
+var newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(d)
+	return t.C, t.Stop
+}
+
+// WrapAutoFlush wraps rwc like Wrap, though all writes are
+// buffered and flushed every interval, and on Close. This
+// turns many tiny writes into periodic frames.
+func WrapAutoFlush(rwc io.ReadWriteCloser, interval time.Duration) *Ansi {
+	a := Wrap(rwc)
+	a.wbuff = &bytes.Buffer{}
+	a.stop = make(chan struct{})
+	tick, stop := newTicker(interval)
+	go func(done chan struct{}) {
+		defer stop()
+		for {
+			select {
+			case <-tick:
+				//only the plain buffer, a frame
+				//is left to EndFrame or Flush
+				a.wmu.Lock()
+				a.flush()
+				a.wmu.Unlock()
+			case <-done:
+				return
+			}
+		}
+	}(a.stop)
+	return a
+}
+
+// Flush writes out any buffered output, along with the
+// bytes WriteAtomic held back, ending a batch started by Begin
+func (a *Ansi) Flush() error {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	if err := a.writePartial(); err != nil {
+		return err
+	}
+	if a.frame != nil {
+		b := a.frame.Bytes()
+		a.frame = nil
+		if len(b) > 0 {
+			if _, err := a.write(b); err != nil {
+				return err
+			}
+		}
+	}
+	return a.flush()
+}
+
+// Begin holds back all following output until Flush, which
+// writes it in a single write. It is BeginFrame, without the
+// synchronized output markers.
+func (a *Ansi) Begin() {
+	a.BeginFrame()
+}
+
+// Batch runs fn between Begin and Flush, so all it writes
+// goes out at once, even when fn panics
+func (a *Ansi) Batch(fn func()) (err error) {
+	a.Begin()
+	defer func() {
+		err = a.Flush()
+	}()
+	fn()
+	return nil
+}
+
+func (a *Ansi) flush() error {
+	if a.wbuff == nil || a.wbuff.Len() == 0 {
+		return nil
+	}
+	_, err := a.w.Write(a.wbuff.Bytes())
+	a.wbuff.Reset()
+	return err
+}
@@ -0,0 +1,118 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWrapAutoFlush(t *testing.T) {
+	tick := make(chan time.Time)
+	stopped := make(chan bool, 1)
+	newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		if d != 50*time.Millisecond {
+			t.Errorf("unexpected interval %s", d)
+		}
+		return tick, func() { stopped <- true }
+	}
+	defer func() { newTicker = defaultTicker }()
+
+	f := newFakeTerm()
+	a := WrapAutoFlush(f, 50*time.Millisecond)
+	a.Goto(1, 1)
+	a.Write([]byte("hi"))
+	if got := f.written(); got != "" {
+		t.Fatalf("expected nothing before the tick, got %q", got)
+	}
+	tick <- time.Now()
+	tick <- time.Now() //second tick ensures the first flush completed
+	if got := f.written(); got != "\x1b[1;1Hhi" {
+		t.Fatalf("got %q", got)
+	}
+	a.Write([]byte("!"))
+	a.Close()
+	if got := f.written(); got != "\x1b[1;1Hhi!" {
+		t.Fatalf("expected flush on close, got %q", got)
+	}
+	<-stopped
+}
+
+func TestAutoFlushKeepsFrame(t *testing.T) {
+	tick := make(chan time.Time)
+	newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return tick, func() {}
+	}
+	defer func() { newTicker = defaultTicker }()
+
+	f := newFakeTerm()
+	a := WrapAutoFlush(f, time.Second)
+	defer a.Close()
+	a.Write([]byte("x"))
+	a.BeginFrame()
+	a.Write([]byte("frame"))
+	tick <- time.Now()
+	tick <- time.Now()
+	if got := f.written(); got != "x" {
+		t.Fatalf("expected the tick to leave the frame, got %q", got)
+	}
+	a.EndFrame(false)
+	if got := f.written(); got != "xframe" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestBatchPanic(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	defer a.Close()
+	func() {
+		defer func() { recover() }()
+		a.Batch(func() {
+			a.Write([]byte("hi"))
+			panic("boom")
+		})
+	}()
+	a.Write([]byte("!"))
+	if len(w) != 2 || string(w[0]) != "hi" || string(w[1]) != "!" {
+		t.Fatalf("expected the batch to end, got %q", w)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return nil, func() {}
+	}
+	defer func() { newTicker = defaultTicker }()
+
+	f := newFakeTerm()
+	a := WrapAutoFlush(f, time.Second)
+	defer a.Close()
+	a.Write([]byte("x"))
+	a.Flush()
+	if got := f.written(); got != "x" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	defer a.Close()
+	err := a.Batch(func() {
+		a.Goto(1, 1)
+		a.Set(Red)
+		a.Write([]byte("hi"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Begin()
+	a.CursorHide()
+	a.EraseLine()
+	if len(w) != 1 {
+		t.Fatalf("expected nothing written before Flush, got %q", w)
+	}
+	a.Flush()
+	if len(w) != 2 || string(w[0]) != "\x1b[1;1H\x1b[31mhi" || string(w[1]) != "\x1b[?25l\x1b[2K" {
+		t.Fatalf("expected one write per batch, got %q", w)
+	}
+}
@@ -0,0 +1,16 @@
+package ansi
+
+// Enable Focus Reporting	<ESC>[?1004h
+// Disable Focus Reporting	<ESC>[?1004l
+var EnableFocusReporting = []byte{Esc, '[', '?', '1', '0', '0', '4', 'h'}
+var DisableFocusReporting = []byte{Esc, '[', '?', '1', '0', '0', '4', 'l'}
+
+// EnableFocusReporting asks the terminal to report when it gains
+// and loses focus, as Focus and Blur reports
+func (a *Ansi) EnableFocusReporting() {
+	a.Write(EnableFocusReporting)
+}
+
+func (a *Ansi) DisableFocusReporting() {
+	a.Write(DisableFocusReporting)
+}
@@ -0,0 +1,29 @@
+package ansi
+
+import "testing"
+
+func TestFocusReports(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableFocusReporting()
+	a.DisableFocusReporting()
+	if got, want := f.written(), "\x1b[?1004h\x1b[?1004l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	go f.send("a\x1b[Ib\x1b[2Ic\x1b[O")
+	buf := make([]byte, 8)
+	var data []byte
+	for len(data) < 3 {
+		n, _ := a.Read(buf)
+		data = append(data, buf[:n]...)
+	}
+	if string(data) != "abc" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	for _, want := range []ReportType{Focus, Blur} {
+		if r := <-a.Reports; r.Type != want {
+			t.Fatalf("expected %s, got %+v", reportNames[want], r)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package ansi
+
+import "bytes"
+
+// BeginFrame holds back all following output until EndFrame
+func (a *Ansi) BeginFrame() {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	if a.frame == nil {
+		a.frame = &bytes.Buffer{}
+	}
+}
+
+// EndFrame writes out the output held since BeginFrame in a single
+// write, so it cannot tear or interleave with other writers. With
+// sync the frame is bracketed by the synchronized output markers
+// as well, which terminals without mode 2026 ignore.
+func (a *Ansi) EndFrame(sync bool) error {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	if a.frame == nil {
+		return nil
+	}
+	b := a.frame.Bytes()
+	a.frame = nil
+	if sync {
+		begin, end := BeginSync, EndSync
+		if a.c1 {
+			begin, end = to8Bit(begin), to8Bit(end)
+		}
+		b = append(append(append([]byte{}, begin...), b...), end...)
+	}
+	if len(b) == 0 {
+		return nil
+	}
+	if _, err := a.write(b); err != nil {
+		return err
+	}
+	return a.flush()
+}
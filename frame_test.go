@@ -0,0 +1,26 @@
+package ansi
+
+import "testing"
+
+func TestFrame(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	a.BeginFrame()
+	a.Goto(1, 1)
+	a.Set(Red)
+	a.Write([]byte("hi"))
+	if len(w) != 0 {
+		t.Fatalf("expected nothing before EndFrame, got %q", w)
+	}
+	if err := a.EndFrame(true); err != nil {
+		t.Fatal(err)
+	}
+	want := string(BeginSync) + string(Goto(1, 1)) + string(Set(Red)) + "hi" + string(EndSync)
+	if len(w) != 1 || string(w[0]) != want {
+		t.Fatalf("expected a single write %q, got %q", want, w)
+	}
+	a.Write([]byte("after"))
+	if len(w) != 2 {
+		t.Fatalf("expected writes to go straight out after the frame, got %q", w)
+	}
+}
@@ -0,0 +1,34 @@
+package ansi
+
+import "bytes"
+
+// SplitFrames splits a recorded stream into frames on the
+// synchronized output markers, for frame accurate playback. Each
+// BeginSync..EndSync section is a frame, without its markers, and
+// so is any output between sections. An unterminated section runs
+// to the end of the stream.
+func SplitFrames(b []byte) [][]byte {
+	var frames [][]byte
+	add := func(f []byte) {
+		if len(f) > 0 {
+			frames = append(frames, f)
+		}
+	}
+	for len(b) > 0 {
+		i := bytes.Index(b, BeginSync)
+		if i < 0 {
+			add(b)
+			break
+		}
+		add(b[:i])
+		b = b[i+len(BeginSync):]
+		j := bytes.Index(b, EndSync)
+		if j < 0 {
+			add(b)
+			break
+		}
+		add(b[:j])
+		b = b[j+len(EndSync):]
+	}
+	return frames
+}
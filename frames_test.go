@@ -0,0 +1,21 @@
+package ansi
+
+import "testing"
+
+func TestSplitFrames(t *testing.T) {
+	sync := func(s string) string { return string(BeginSync) + s + string(EndSync) }
+	stream := "intro" + sync("\x1b[Hone") + sync("\x1b[Htwo") + "\n" + string(BeginSync) + "cut"
+	got := SplitFrames([]byte(stream))
+	want := []string{"intro", "\x1b[Hone", "\x1b[Htwo", "\n", "cut"}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Fatalf("got %q, expected %q", got, want)
+		}
+	}
+	if got := SplitFrames(nil); got != nil {
+		t.Fatalf("expected no frames, got %q", got)
+	}
+}
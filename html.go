@@ -0,0 +1,176 @@
+package ansi
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// HTMLOptions configure NewHTMLWriter
+type HTMLOptions struct {
+	//Palette holds the 16 basic colors,
+	//CSS names and xterm colors are used when nil
+	Palette []Color
+	//Foreground and Background are used when
+	//Reverse swaps in a default color
+	Foreground, Background Color
+	//Plain leaves out the styling, writing only
+	//the escaped text
+	Plain bool
+}
+
+// NewHTMLWriter returns a writer which converts the ANSI text written
+// to it to HTML, each styled run in a <span> with inline CSS, for a
+// <pre> element. Spans are closed at the end of each Write so every
+// Write is complete HTML. opts may be nil. For plain text, without
+// HTML escaping, use NewStripWriter.
+func NewHTMLWriter(w io.Writer, opts *HTMLOptions) io.Writer {
+	h := &htmlWriter{w: w}
+	if opts != nil {
+		h.opts = *opts
+	} else {
+		h.opts.Foreground = xtermColors[7]
+	}
+	return h
+}
+
+type htmlWriter struct {
+	w       io.Writer
+	opts    HTMLOptions
+	partial []byte
+	active  []Attribute
+}
+
+func (h *htmlWriter) Write(p []byte) (int, error) {
+	b := append(h.partial, p...)
+	h.partial = nil
+	if i := incomplete(b); i >= 0 {
+		h.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	var out strings.Builder
+	open, style := false, ""
+	s := string(b)
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			if params, m := sgrParams(s[i:]); m > 0 {
+				for _, attr := range splitSGR(params) {
+					h.active = applyAttribute(h.active, Attribute(attr))
+				}
+			}
+			i += n
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] != Esc {
+			j++
+		}
+		if next := h.css(); !h.opts.Plain && (next != style || !open) {
+			if open {
+				out.WriteString("</span>")
+				open = false
+			}
+			if style = next; style != "" {
+				fmt.Fprintf(&out, `<span style="%s">`, style)
+				open = true
+			}
+		}
+		out.WriteString(html.EscapeString(s[i:j]))
+		i = j
+	}
+	if open {
+		out.WriteString("</span>")
+	}
+	if out.Len() > 0 {
+		if _, err := io.WriteString(h.w, out.String()); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// css returns the inline CSS of the active attributes
+func (h *htmlWriter) css() string {
+	var fg, bg string
+	var rules []string
+	reverse := false
+	for _, a := range h.active {
+		switch a {
+		case Bright:
+			rules = append(rules, "font-weight:bold")
+		case Dim:
+			rules = append(rules, "opacity:0.5")
+		case Italic:
+			rules = append(rules, "font-style:italic")
+		case Underscore:
+			rules = append(rules, "text-decoration:underline")
+		case Reverse:
+			reverse = true
+		case Hidden:
+			rules = append(rules, "visibility:hidden")
+		default:
+			switch sgrSlot(string(a)) {
+			case "fg":
+				fg = h.color(a)
+			case "bg":
+				bg = h.color(a)
+			}
+		}
+	}
+	if reverse {
+		if fg == "" {
+			fg = hexColor(h.opts.Foreground)
+		}
+		if bg == "" {
+			bg = hexColor(h.opts.Background)
+		}
+		fg, bg = bg, fg
+	}
+	if fg != "" {
+		rules = append(rules, "color:"+fg)
+	}
+	if bg != "" {
+		rules = append(rules, "background-color:"+bg)
+	}
+	return strings.Join(rules, ";")
+}
+
+// color returns the CSS color of a, taking the
+// basic colors from the palette when there is one
+func (h *htmlWriter) color(a Attribute) string {
+	if p := h.opts.Palette; len(p) >= 16 {
+		if i := basicIndex(a); i >= 0 {
+			return hexColor(p[i])
+		}
+	}
+	css, _ := a.CSS()
+	return css
+}
+
+// basicIndex returns the 16 color palette index
+// of a color attribute, or -1
+func basicIndex(a Attribute) int {
+	s := string(a)
+	if strings.HasPrefix(s, "38;5;") || strings.HasPrefix(s, "48;5;") {
+		if n, err := strconv.Atoi(s[5:]); err == nil && n >= 0 && n < 16 {
+			return n
+		}
+		return -1
+	}
+	n, err := strconv.Atoi(s)
+	switch {
+	case err != nil:
+	case n >= 30 && n <= 37, n >= 40 && n <= 47:
+		return n % 10
+	case n >= 90 && n <= 97, n >= 100 && n <= 107:
+		return 8 + n%10
+	}
+	return -1
+}
+
+func hexColor(c Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,43 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestHTMLWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewHTMLWriter(&buf, nil)
+	w.Write([]byte("a<b " + string(Set(Red, Bright)) + "red" + string(Set(Reset))))
+	w.Write([]byte("\x1b[4"))
+	w.Write([]byte("4mblue\x1b[0m & done"))
+	want := `a&lt;b <span style="font-weight:bold;color:red">red</span>` +
+		`<span style="background-color:blue">blue</span> &amp; done`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+}
+
+func TestHTMLWriterPalette(t *testing.T) {
+	palette := make([]Color, 16)
+	palette[1] = Color{1, 2, 3}
+	var buf bytes.Buffer
+	w := NewHTMLWriter(&buf, &HTMLOptions{Palette: palette, Foreground: Color{255, 255, 255}})
+	w.Write([]byte("\x1b[31mx\x1b[7my\x1b[0;38;5;1mz\x1b[38;5;196mw"))
+	want := `<span style="color:#010203">x</span>` +
+		`<span style="color:#000000;background-color:#010203">y</span>` +
+		`<span style="color:#010203">z</span>` +
+		`<span style="color:#ff0000">w</span>`
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+}
+
+func TestHTMLWriterPlain(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewHTMLWriter(&buf, &HTMLOptions{Plain: true})
+	w.Write([]byte("\x1b[1m<x>\x1b[0m\x1b[2J y"))
+	if got := buf.String(); got != "&lt;x&gt; y" {
+		t.Errorf("got %q", got)
+	}
+}
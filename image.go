@@ -0,0 +1,154 @@
+package ansi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strconv"
+	"time"
+)
+
+// iTerm2 Inline Image	<ESC>]1337;File=inline=1;size={n};width={w}px;height={h}px:{base64 png}<ESC>\
+func EncodeITerm2(img image.Image) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil
+	}
+	size := img.Bounds().Size()
+	return iterm("File=inline=1;size=" + strconv.Itoa(buf.Len()) +
+		";width=" + strconv.Itoa(size.X) + "px;height=" + strconv.Itoa(size.Y) + "px:" +
+		base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// Sixel Image		<ESC>P0;1;0q"1;1;{w};{h}{colors}{sixels}<ESC>\
+//
+// EncodeSixel draws img as sixels, each pixel in the nearest
+// color of a 6x6x6 color cube, mostly transparent pixels are
+// left out
+func EncodeSixel(img image.Image) []byte {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	b := []byte{Esc, 'P', '0', ';', '1', ';', '0', 'q', '"', '1', ';', '1', ';'}
+	b = strconv.AppendInt(b, int64(w), 10)
+	b = append(b, ';')
+	b = strconv.AppendInt(b, int64(h), 10)
+	//the cube index of each pixel, -1 when transparent
+	pixels := make([]int, w*h)
+	used := make([]bool, 216)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			r, g, bl, a := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			if a < 0x8000 {
+				pixels[y*w+x] = -1
+				continue
+			}
+			//undo the alpha premultiplication
+			level := func(v uint32) int { return int((v*0xffff/a*5 + 0x7fff) / 0xffff) }
+			i := level(r)*36 + level(g)*6 + level(bl)
+			pixels[y*w+x] = i
+			used[i] = true
+		}
+	}
+	for i, u := range used {
+		if !u {
+			continue
+		}
+		b = append(b, '#')
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, ';', '2')
+		for _, l := range []int{i / 36, i / 6 % 6, i % 6} {
+			b = append(b, ';')
+			b = strconv.AppendInt(b, int64(l*20), 10)
+		}
+	}
+	for y0 := 0; y0 < h; y0 += 6 {
+		first := true
+		for c, u := range used {
+			if !u {
+				continue
+			}
+			line := make([]byte, w)
+			any := false
+			for x := 0; x < w; x++ {
+				bits := 0
+				for i := 0; i < 6 && y0+i < h; i++ {
+					if pixels[(y0+i)*w+x] == c {
+						bits |= 1 << uint(i)
+					}
+				}
+				line[x] = byte(0x3f + bits)
+				any = any || bits != 0
+			}
+			if !any {
+				continue
+			}
+			if !first {
+				b = append(b, '$')
+			}
+			first = false
+			b = append(b, '#')
+			b = strconv.AppendInt(b, int64(c), 10)
+			b = appendSixels(b, line)
+		}
+		b = append(b, '-')
+	}
+	return append(b, Esc, '\\')
+}
+
+// appendSixels appends line, with runs
+// of the same sixel as !{count}{sixel}
+func appendSixels(b, line []byte) []byte {
+	for i := 0; i < len(line); {
+		j := i + 1
+		for j < len(line) && line[j] == line[i] {
+			j++
+		}
+		if n := j - i; n > 3 {
+			b = append(b, '!')
+			b = strconv.AppendInt(b, int64(n), 10)
+			b = append(b, line[i])
+		} else {
+			b = append(b, line[i:j]...)
+		}
+		i = j
+	}
+	return b
+}
+
+// ErrNoImages is returned by DrawImage when the
+// terminal shows neither iTerm2 nor sixel images
+var ErrNoImages = errors.New("Terminal does not show images")
+
+// SupportsSixel asks the terminal for its device
+// attributes, which include 4 when it shows sixels
+func (a *Ansi) SupportsSixel(timeout time.Duration) bool {
+	r, err := a.query(QueryCode, isType(Code), timeout)
+	if err != nil {
+		return false
+	}
+	for _, p := range r.Params {
+		if p == 4 {
+			return true
+		}
+	}
+	return false
+}
+
+// DrawImage draws img with its top left corner at row and col,
+// as an iTerm2 image when running in iTerm2, otherwise as sixels
+// when the terminal supports them
+func (a *Ansi) DrawImage(img image.Image, row, col uint16) error {
+	var b []byte
+	switch {
+	case getenv("TERM_PROGRAM") == "iTerm.app":
+		b = EncodeITerm2(img)
+	case a.SupportsSixel(positionTimeout):
+		b = EncodeSixel(img)
+	default:
+		return ErrNoImages
+	}
+	_, err := a.Write(append(Goto(row, col), b...))
+	return err
+}
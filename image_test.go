@@ -0,0 +1,65 @@
+package ansi
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"strings"
+	"testing"
+)
+
+func testImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 5, 2))
+	for x := 0; x < 5; x++ {
+		img.Set(x, 0, color.NRGBA{255, 0, 0, 255})
+	}
+	img.Set(0, 1, color.NRGBA{0, 0, 255, 255})
+	return img
+}
+
+func TestEncodeSixel(t *testing.T) {
+	got := string(EncodeSixel(testImage()))
+	want := "\x1bP0;1;0q\"1;1;5;2" +
+		"#5;2;0;0;100#180;2;100;0;0" +
+		"#5A!4?$#180!5@-\x1b\\"
+	if got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestEncodeITerm2(t *testing.T) {
+	got := string(EncodeITerm2(testImage()))
+	if !strings.HasPrefix(got, "\x1b]1337;File=inline=1;size=") ||
+		!strings.Contains(got, ";width=5px;height=2px:iVBORw0KGgo") ||
+		!strings.HasSuffix(got, "\x1b\\") {
+		t.Fatalf("unexpected %q", got)
+	}
+}
+
+func TestDrawImage(t *testing.T) {
+	getenv = func(string) string { return "" }
+	defer func() { getenv = os.Getenv }()
+	f := newFakeTerm()
+	sixel := false
+	f.reply = func(q string) string {
+		if q == string(QueryCode) && sixel {
+			return "\x1b[?62;4;22c"
+		}
+		if q == string(QueryCode) {
+			return "\x1b[?62;22c"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	if err := a.DrawImage(testImage(), 1, 1); err != ErrNoImages {
+		t.Fatalf("expected ErrNoImages, got %v", err)
+	}
+	sixel = true
+	if err := a.DrawImage(testImage(), 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if got := f.written(); !strings.HasSuffix(got, "\x1b[2;3H"+string(EncodeSixel(testImage()))) {
+		t.Fatalf("unexpected output %q", got)
+	}
+}
@@ -0,0 +1,29 @@
+package ansi
+
+import "strconv"
+
+// Insert Mode		<ESC>[4h
+// Replace Mode		<ESC>[4l
+// While in insert mode (IRM) written text pushes the rest of the line
+// to the right instead of overwriting it. InsertChars opens up blank
+// cells at the cursor regardless of the mode.
+var EnableInsertMode = []byte{Esc, '[', '4', 'h'}
+var DisableInsertMode = []byte{Esc, '[', '4', 'l'}
+
+func (a *Ansi) EnableInsertMode() {
+	a.Write(EnableInsertMode)
+}
+
+func (a *Ansi) DisableInsertMode() {
+	a.Write(DisableInsertMode)
+}
+
+// Insert Characters	<ESC>[{COUNT}@
+func InsertChars(n uint16) []byte {
+	b := append([]byte{Esc, '['}, strconv.Itoa(int(n))...)
+	return append(b, '@')
+}
+
+func (a *Ansi) InsertChars(n uint16) {
+	a.Write(InsertChars(n))
+}
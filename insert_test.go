@@ -0,0 +1,15 @@
+package ansi
+
+import "testing"
+
+func TestInsertMode(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableInsertMode()
+	a.InsertChars(3)
+	a.DisableInsertMode()
+	if got := f.written(); got != "\x1b[4h\x1b[3@\x1b[4l" {
+		t.Fatalf("got %q", got)
+	}
+}
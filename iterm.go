@@ -0,0 +1,61 @@
+package ansi
+
+import (
+	"encoding/base64"
+	"os"
+)
+
+// iTerm2 Set Mark		<ESC>]1337;SetMark<ESC>\
+// iTerm2 Set Badge		<ESC>]1337;SetBadgeFormat={base64 format}<ESC>\
+// iTerm2 Annotate Line	<ESC>]1337;AddAnnotation={message}<ESC>\
+var ITermMark = iterm("SetMark")
+
+func ITermBadge(format string) []byte {
+	return iterm("SetBadgeFormat=" + base64.StdEncoding.EncodeToString([]byte(format)))
+}
+
+func ITermAnnotation(message string) []byte {
+	return iterm("AddAnnotation=" + stripControls(message))
+}
+
+func iterm(body string) []byte {
+	b := append([]byte{Esc, ']', '1', '3', '3', '7', ';'}, body...)
+	return append(b, Esc, '\\')
+}
+
+// getenv is replaced in tests
+var getenv = os.Getenv
+
+// ITerm writes the iTerm2 proprietary sequences
+type ITerm struct {
+	a *Ansi
+	//Detect, when set, makes the methods no-ops unless
+	//$TERM_PROGRAM shows this is running in iTerm2
+	Detect bool
+}
+
+func (a *Ansi) ITerm() *ITerm {
+	return &ITerm{a: a}
+}
+
+func (t *ITerm) write(b []byte) {
+	if t.Detect && getenv("TERM_PROGRAM") != "iTerm.app" {
+		return
+	}
+	t.a.Write(b)
+}
+
+// SetMark marks the current line, for navigating between marks
+func (t *ITerm) SetMark() {
+	t.write(ITermMark)
+}
+
+// SetBadge shows format as a badge over the session
+func (t *ITerm) SetBadge(format string) {
+	t.write(ITermBadge(format))
+}
+
+// AnnotateLine attaches a note to the current line
+func (t *ITerm) AnnotateLine(message string) {
+	t.write(ITermAnnotation(message))
+}
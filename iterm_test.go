@@ -0,0 +1,43 @@
+package ansi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestITerm(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	i := a.ITerm()
+	i.SetMark()
+	i.SetBadge("hi")
+	i.AnnotateLine("note\x1b")
+	want := "\x1b]1337;SetMark\x1b\\" +
+		"\x1b]1337;SetBadgeFormat=aGk=\x1b\\" +
+		"\x1b]1337;AddAnnotation=note\x1b\\"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestITermDetect(t *testing.T) {
+	program := "Apple_Terminal"
+	getenv = func(string) string { return program }
+	defer func() { getenv = os.Getenv }()
+
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	i := a.ITerm()
+	i.Detect = true
+	i.SetMark()
+	if got := f.written(); got != "" {
+		t.Fatalf("expected nothing outside iTerm2, got %q", got)
+	}
+	program = "iTerm.app"
+	i.SetMark()
+	if got := f.written(); got != string(ITermMark) {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,217 @@
+package ansi
+
+import "unicode/utf8"
+
+// Key identifies the key of a KeyEvent
+type Key int
+
+const (
+	//KeyRune is a printable key, held in Rune
+	KeyRune Key = iota
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyEscape
+	KeyUp
+	KeyDown
+	KeyRight
+	KeyLeft
+	KeyHome
+	KeyEnd
+	KeyInsert
+	KeyDelete
+	KeyPgUp
+	KeyPgDn
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// Modifier is a set of modifier keys
+type Modifier int
+
+const (
+	ModShift Modifier = 1 << iota
+	ModAlt
+	ModCtrl
+)
+
+// KeyEvent is a key press. Control characters are read as the
+// letter they are typed with plus ModCtrl, so Ctrl+C is the Rune
+// 'c' with ModCtrl.
+type KeyEvent struct {
+	Key  Key
+	Rune rune
+	Mod  Modifier
+}
+
+// Cursor Key		<ESC>[{1;MOD}{A|B|C|D|H|F} or <ESC>O{A|B|C|D|H|F}
+// Function Key		<ESC>O{P|Q|R|S} or <ESC>[{1;MOD}{P|Q|R|S}
+// Editing Key		<ESC>[{n}{;MOD}~
+// Back Tab		<ESC>[Z
+var cursorKeys = map[byte]Key{
+	'A': KeyUp, 'B': KeyDown, 'C': KeyRight, 'D': KeyLeft,
+	'H': KeyHome, 'F': KeyEnd,
+	'P': KeyF1, 'Q': KeyF2, 'R': KeyF3, 'S': KeyF4,
+}
+
+var tildeKeys = map[int]Key{
+	1: KeyHome, 2: KeyInsert, 3: KeyDelete, 4: KeyEnd,
+	5: KeyPgUp, 6: KeyPgDn, 7: KeyHome, 8: KeyEnd,
+	11: KeyF1, 12: KeyF2, 13: KeyF3, 14: KeyF4, 15: KeyF5,
+	17: KeyF6, 18: KeyF7, 19: KeyF8, 20: KeyF9, 21: KeyF10,
+	23: KeyF11, 24: KeyF12,
+}
+
+// ParseKeys decodes the key presses in b, as typed into a
+// terminal. Unknown escape sequences are skipped.
+func ParseKeys(b []byte) []KeyEvent {
+	var keys []KeyEvent
+	s := string(b)
+	for i := 0; i < len(s); {
+		if s[i] != Esc {
+			k, n := parseKey(s[i:])
+			keys = append(keys, k)
+			i += n
+			continue
+		}
+		if i+1 == len(s) {
+			keys = append(keys, KeyEvent{Key: KeyEscape})
+			break
+		}
+		//<ESC>O{final}, any other <ESC>O is alt+O
+		if s[i+1] == 'O' && i+2 < len(s) {
+			if k, ok := cursorKeys[s[i+2]]; ok {
+				keys = append(keys, KeyEvent{Key: k})
+				i += 3
+				continue
+			}
+		}
+		switch s[i+1] {
+		case '[':
+			n, _ := sequenceLen(s[i:])
+			if k, ok := parseKeySequence(s[i+2 : i+n]); ok {
+				keys = append(keys, k)
+			}
+			i += n
+		case Esc:
+			keys = append(keys, KeyEvent{Key: KeyEscape})
+			i++
+		default:
+			//alt is sent as an escape before the key
+			k, n := parseKey(s[i+1:])
+			k.Mod |= ModAlt
+			keys = append(keys, k)
+			i += 1 + n
+		}
+	}
+	return keys
+}
+
+// parseKey decodes the key at the start of s, which is not an escape
+func parseKey(s string) (KeyEvent, int) {
+	switch c := s[0]; {
+	case c == '\r' || c == '\n':
+		return KeyEvent{Key: KeyEnter}, 1
+	case c == '\t':
+		return KeyEvent{Key: KeyTab}, 1
+	case c == 0x7f || c == 0x08:
+		return KeyEvent{Key: KeyBackspace}, 1
+	case c == 0:
+		return KeyEvent{Rune: ' ', Mod: ModCtrl}, 1
+	case c <= 26:
+		return KeyEvent{Rune: rune('a' + c - 1), Mod: ModCtrl}, 1
+	case c < 0x20:
+		return KeyEvent{Rune: rune(c + 0x40), Mod: ModCtrl}, 1
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return KeyEvent{Rune: r}, n
+}
+
+// parseKeySequence decodes the body of a CSI key sequence
+func parseKeySequence(body string) (KeyEvent, bool) {
+	prefix, p, inter, final, _ := parseControl(body)
+	if prefix != 0 || inter != "" {
+		return KeyEvent{}, false
+	}
+	var k KeyEvent
+	switch final {
+	case 'Z':
+		return KeyEvent{Key: KeyTab, Mod: ModShift}, true
+	case '~':
+		key, ok := 0, false
+		if len(p) > 0 {
+			key = p[0]
+		}
+		if k.Key, ok = tildeKeys[key]; !ok {
+			return k, false
+		}
+	default:
+		key, ok := cursorKeys[final]
+		if !ok {
+			return k, false
+		}
+		k.Key = key
+	}
+	//xterm sends the modifiers plus one
+	if len(p) > 1 && p[1] > 1 {
+		m := p[1] - 1
+		if m&1 != 0 {
+			k.Mod |= ModShift
+		}
+		if m&2 != 0 {
+			k.Mod |= ModAlt
+		}
+		if m&4 != 0 {
+			k.Mod |= ModCtrl
+		}
+	}
+	return k, true
+}
+
+// Keys returns a channel of the key presses read, which is closed
+// once reading stops. Once called, the data read is decoded into
+// keys rather than returned by Read, reports still arrive on the
+// Reports queue.
+func (a *Ansi) Keys() <-chan KeyEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.keys != nil {
+		return a.keys
+	}
+	a.keys = make(chan KeyEvent, ReportsBuffer)
+	go func(keys chan KeyEvent) {
+		defer close(keys)
+		for _, k := range ParseKeys(a.leftover()) {
+			keys <- k
+		}
+		for {
+			e, err := a.next()
+			if err != nil {
+				return
+			}
+			d, ok := e.(DataEvent)
+			if !ok {
+				continue
+			}
+			a.answered(d.Bytes)
+			for _, k := range ParseKeys(d.Bytes) {
+				select {
+				case keys <- k:
+				case <-a.closed:
+					return
+				}
+			}
+		}
+	}(a.keys)
+	return a.keys
+}
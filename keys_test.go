@@ -0,0 +1,59 @@
+package ansi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKeys(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		keys []KeyEvent
+	}{
+		{"a\r", []KeyEvent{{Rune: 'a'}, {Key: KeyEnter}}},
+		{"é\t\x7f", []KeyEvent{{Rune: 'é'}, {Key: KeyTab}, {Key: KeyBackspace}}},
+		{"\x03", []KeyEvent{{Rune: 'c', Mod: ModCtrl}}},
+		{"\x1bx", []KeyEvent{{Rune: 'x', Mod: ModAlt}}},
+		{"\x1b", []KeyEvent{{Key: KeyEscape}}},
+		{"\x1b[A\x1b[B\x1b[C\x1b[D", []KeyEvent{{Key: KeyUp}, {Key: KeyDown}, {Key: KeyRight}, {Key: KeyLeft}}},
+		{"\x1bOA\x1bOH\x1bOF", []KeyEvent{{Key: KeyUp}, {Key: KeyHome}, {Key: KeyEnd}}},
+		{"\x1bO", []KeyEvent{{Rune: 'O', Mod: ModAlt}}},
+		{"\x1bOx", []KeyEvent{{Rune: 'O', Mod: ModAlt}, {Rune: 'x'}}},
+		{"\x1bO日", []KeyEvent{{Rune: 'O', Mod: ModAlt}, {Rune: '日'}}},
+		{"\x1b[H\x1b[F\x1b[1~\x1b[4~", []KeyEvent{{Key: KeyHome}, {Key: KeyEnd}, {Key: KeyHome}, {Key: KeyEnd}}},
+		{"\x1b[5~\x1b[6~\x1b[2~\x1b[3~", []KeyEvent{{Key: KeyPgUp}, {Key: KeyPgDn}, {Key: KeyInsert}, {Key: KeyDelete}}},
+		{"\x1bOP\x1bOS\x1b[15~\x1b[24~", []KeyEvent{{Key: KeyF1}, {Key: KeyF4}, {Key: KeyF5}, {Key: KeyF12}}},
+		{"\x1b[1;5C\x1b[1;3A\x1b[3;2~\x1b[1;8P", []KeyEvent{
+			{Key: KeyRight, Mod: ModCtrl},
+			{Key: KeyUp, Mod: ModAlt},
+			{Key: KeyDelete, Mod: ModShift},
+			{Key: KeyF1, Mod: ModShift | ModAlt | ModCtrl},
+		}},
+		{"\x1b[Z", []KeyEvent{{Key: KeyTab, Mod: ModShift}}},
+		{"\x1b[99~q", []KeyEvent{{Rune: 'q'}}},
+	} {
+		if got := ParseKeys([]byte(tc.in)); !reflect.DeepEqual(got, tc.keys) {
+			t.Errorf("ParseKeys(%q) = %+v, expected %+v", tc.in, got, tc.keys)
+		}
+	}
+}
+
+func TestKeys(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	keys := a.Keys()
+	go f.send("q\x1b[0n\x1b[A")
+	if k := <-keys; k.Rune != 'q' {
+		t.Fatalf("unexpected key %+v", k)
+	}
+	if k := <-keys; k.Key != KeyUp {
+		t.Fatalf("unexpected key %+v", k)
+	}
+	if r := <-a.Reports; r.Type != OK {
+		t.Fatalf("expected the report to be queued, got %+v", r)
+	}
+	a.Close()
+	if _, open := <-keys; open {
+		t.Fatal("expected the keys to be closed")
+	}
+}
@@ -0,0 +1,10 @@
+package ansi
+
+// KV renders a "key: value" line for status displays, the key
+// styled with keyAttrs and the value with valAttrs, each reset
+// after so the separator between them is left plain
+func KV(key, value string, keyAttrs, valAttrs []Attribute) []byte {
+	b := []byte(WrapString(key, keyAttrs...))
+	b = append(b, ": "...)
+	return append(b, WrapString(value, valAttrs...)...)
+}
@@ -0,0 +1,13 @@
+package ansi
+
+import "testing"
+
+func TestKV(t *testing.T) {
+	got := string(KV("host", "example.com", []Attribute{Bright, Blue}, []Attribute{Green}))
+	if want := "\x1b[1;34mhost\x1b[0m: \x1b[32mexample.com\x1b[0m"; got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+	if got := string(KV("a", "b", nil, nil)); got != "a: b" {
+		t.Errorf("expected no styling, got %q", got)
+	}
+}
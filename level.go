@@ -0,0 +1,23 @@
+package ansi
+
+import "strings"
+
+// LevelColors maps log levels to the color of their prefix
+var LevelColors = map[string]Attribute{
+	"DEBUG": Dim,
+	"INFO":  Cyan,
+	"WARN":  Yellow,
+	"ERROR": Red,
+	"FATAL": Bright + ";" + Red,
+}
+
+// LevelPrefix returns the bracketed level, like [INFO], colored
+// according to LevelColors. Unknown levels are left uncolored.
+func LevelPrefix(level string) []byte {
+	level = strings.ToUpper(level)
+	tag := "[" + level + "]"
+	if attr, ok := LevelColors[level]; ok {
+		return []byte(attr.String(tag))
+	}
+	return []byte(tag)
+}
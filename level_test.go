@@ -0,0 +1,21 @@
+package ansi
+
+import "testing"
+
+func TestLevelPrefix(t *testing.T) {
+	for level, want := range map[string]string{
+		"INFO":  "\x1b[36m[INFO]\x1b[0m",
+		"warn":  "\x1b[33m[WARN]\x1b[0m",
+		"ERROR": "\x1b[31m[ERROR]\x1b[0m",
+		"trace": "[TRACE]",
+	} {
+		if got := string(LevelPrefix(level)); got != want {
+			t.Errorf("LevelPrefix(%q) = %q, expected %q", level, got, want)
+		}
+	}
+	LevelColors["TRACE"] = Magenta
+	defer delete(LevelColors, "TRACE")
+	if got := string(LevelPrefix("trace")); got != "\x1b[35m[TRACE]\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,78 @@
+package ansi
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Carriage Return	\r
+var CarriageReturn = []byte{'\r'}
+
+func (a *Ansi) CarriageReturn() {
+	a.Write(CarriageReturn)
+}
+
+// Move To Column		<ESC>[{COLUMN}G
+func MoveToColumn(col uint16) []byte {
+	b := strconv.AppendUint([]byte{Esc, '['}, uint64(col), 10)
+	return append(b, 'G')
+}
+
+func (a *Ansi) MoveToColumn(col uint16) {
+	a.Write(MoveToColumn(col))
+}
+
+// LineWriter redraws its text in place on each SetText,
+// for spinners and progress bars. The text may span
+// several lines, the cursor is left at its end.
+type LineWriter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	lines int
+}
+
+// NewLineWriter returns a LineWriter drawing to w
+// from the start of the current line
+func NewLineWriter(w io.Writer) *LineWriter {
+	return &LineWriter{w: w}
+}
+
+// SetText replaces the text drawn last with s, in a single Write
+func (l *LineWriter) SetText(s string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := append([]byte{}, CarriageReturn...)
+	if l.lines > 1 {
+		b = append(b, Up(uint16(l.lines-1))...)
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if i > 0 {
+			b = append(b, '\r', '\n')
+		}
+		b = append(b, line...)
+		b = append(b, EraseEndLine...)
+	}
+	if len(lines) < l.lines {
+		//clear what is left of the longer text
+		b = append(b, EraseDown...)
+	}
+	l.lines = len(lines)
+	_, err := l.w.Write(b)
+	return err
+}
+
+// Done leaves the text drawn last in place, moving on to a new
+// line, the next SetText starts from there
+func (l *LineWriter) Done() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.lines == 0 {
+		return nil
+	}
+	l.lines = 0
+	_, err := l.w.Write([]byte{'\r', '\n'})
+	return err
+}
@@ -0,0 +1,41 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestMoveToColumn(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.CarriageReturn()
+	a.MoveToColumn(12)
+	if got, want := f.written(), "\r\x1b[12G"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLineWriter(&buf)
+	l.SetText("10%")
+	l.SetText("20%\nstep 2")
+	l.SetText("done")
+	l.Done()
+	l.SetText("next")
+	want := "\r10%\x1b[K" +
+		"\r20%\x1b[K\r\nstep 2\x1b[K" +
+		"\r\x1b[Adone\x1b[K\x1b[J" +
+		"\r\n" +
+		"\rnext\x1b[K"
+	if got := buf.String(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	//as left on the screen
+	s := NewScreen(3, 10)
+	s.Write(buf.Bytes())
+	if got := s.String(); got != "done\nnext\n" {
+		t.Fatalf("got %q", got)
+	}
+}
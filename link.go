@@ -0,0 +1,59 @@
+package ansi
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Hyperlink		<ESC>]8;;{url}<ESC>\{text}<ESC>]8;;<ESC>\
+func Hyperlink(url, text string) []byte {
+	b := append([]byte{Esc, ']', '8', ';', ';'}, stripControls(url)...)
+	b = append(b, Esc, '\\')
+	b = append(b, text...)
+	return append(b, Esc, ']', '8', ';', ';', Esc, '\\')
+}
+
+func (a *Ansi) Hyperlink(url, text string) {
+	a.Write(Hyperlink(url, text))
+}
+
+// FileLink writes text as a link to the file at path, relative
+// paths are resolved against the current directory
+func (a *Ansi) FileLink(path, text string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	//windows paths start with the volume, not a slash
+	if abs = filepath.ToSlash(abs); !strings.HasPrefix(abs, "/") {
+		abs = "/" + abs
+	}
+	host, _ := os.Hostname()
+	_, err = a.Write(Hyperlink(fileURL(host, abs), text))
+	return err
+}
+
+// urlRegexp matches http(s) URLs, conservatively: no
+// whitespace, quotes, brackets or escape sequences
+var urlRegexp = regexp.MustCompile(`https?://[^\s<>"'\x1b\x07]+`)
+
+// Linkify returns s with each URL in it made a Hyperlink,
+// trailing punctuation is left out of the URL
+func Linkify(s string) []byte {
+	var b []byte
+	last := 0
+	for _, m := range urlRegexp.FindAllStringIndex(s, -1) {
+		url := strings.TrimRight(s[m[0]:m[1]], ".,;:!?)]}")
+		b = append(b, s[last:m[0]]...)
+		b = append(b, Hyperlink(url, url)...)
+		last = m[0] + len(url)
+	}
+	return append(b, s[last:]...)
+}
+
+// WriteLinkified writes s with the URLs in it made links
+func (a *Ansi) WriteLinkified(s string) {
+	a.Write(Linkify(s))
+}
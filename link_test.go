@@ -0,0 +1,44 @@
+package ansi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHyperlink(t *testing.T) {
+	want := "\x1b]8;;https://example.com\x1b\\site\x1b]8;;\x1b\\"
+	if got := string(Hyperlink("https://example.com", "site")); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestFileLink(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	if err := a.FileLink("my file.go", "here"); err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	host, _ := os.Hostname()
+	url := fileURL(host, filepath.ToSlash(wd)) + "/my%20file.go"
+	if got, want := f.written(), string(Hyperlink(url, "here")); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestWriteLinkified(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.WriteLinkified("see https://example.com/a?b=1. or (http://x.org) now")
+	want := "see " + string(Hyperlink("https://example.com/a?b=1", "https://example.com/a?b=1")) +
+		". or (" + string(Hyperlink("http://x.org", "http://x.org")) + ") now"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if got := string(Linkify("no links here")); got != "no links here" {
+		t.Fatalf("expected plain text untouched, got %q", got)
+	}
+}
@@ -0,0 +1,47 @@
+package ansi
+
+import (
+	"math"
+	"time"
+)
+
+// Luminance is the relative luminance of c, from 0 for
+// black to 1 for white, as defined by WCAG
+func (c Color) Luminance() float64 {
+	linear := func(v uint8) float64 {
+		f := float64(v) / 255
+		if f <= 0.03928 {
+			return f / 12.92
+		}
+		return math.Pow((f+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
+
+// IsDarkBackground asks the terminal for its background color
+// and reports whether it is dark, meaning white text contrasts
+// with it better than black does. When the terminal doesn't
+// answer the guess is dark, along with ErrTimeout.
+func (a *Ansi) IsDarkBackground(timeout time.Duration) (bool, error) {
+	a.mu.Lock()
+	cached := a.colors
+	a.mu.Unlock()
+	bg := Color{}
+	if cached != nil {
+		bg = cached[1]
+	} else {
+		r, err := a.query(QueryBackground, func(r *Report) bool {
+			return r.Type == OSC && r.Code == 11
+		}, timeout)
+		if err != nil {
+			return true, err
+		}
+		c, ok := parseXColor(r.Text)
+		if !ok {
+			return true, nil
+		}
+		bg = c
+	}
+	//the luminance at which black and white have equal contrast
+	return bg.Luminance() < 0.179, nil
+}
@@ -0,0 +1,43 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsDarkBackground(t *testing.T) {
+	for _, tc := range []struct {
+		reply string
+		dark  bool
+	}{
+		{"\x1b]11;rgb:1e1e/1e1e/2e2e\x07", true},
+		{"\x1b]11;rgb:ffff/fdfd/f6f6\x1b\\", false},
+		{"\x1b]11;rgb:80/80/80\x07", false},
+	} {
+		f := newFakeTerm()
+		f.reply = func(q string) string {
+			if q != string(QueryBackground) {
+				t.Errorf("unexpected query %q", q)
+			}
+			return tc.reply
+		}
+		a := Wrap(f)
+		dark, err := a.IsDarkBackground(time.Second)
+		a.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dark != tc.dark {
+			t.Errorf("%q: dark = %v, expected %v", tc.reply, dark, tc.dark)
+		}
+	}
+}
+
+func TestIsDarkBackgroundTimeout(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	if dark, err := a.IsDarkBackground(time.Millisecond); !dark || err != ErrTimeout {
+		t.Fatalf("expected a dark guess and a timeout, got %v %v", dark, err)
+	}
+}
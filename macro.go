@@ -0,0 +1,44 @@
+package ansi
+
+import "bytes"
+
+// Record starts capturing the output into the macro name, until
+// StopRecord. The output is still written as usual. Recording a
+// name again replaces it, and a recording in progress is stopped.
+func (a *Ansi) Record(name string) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	a.stopRecord()
+	a.recording = name
+	a.record = &bytes.Buffer{}
+}
+
+// StopRecord ends the recording started by Record
+func (a *Ansi) StopRecord() {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	a.stopRecord()
+}
+
+// stopRecord saves the macro being recorded, wmu must be held
+func (a *Ansi) stopRecord() {
+	if a.record == nil {
+		return
+	}
+	if a.macros == nil {
+		a.macros = map[string][]byte{}
+	}
+	a.macros[a.recording] = a.record.Bytes()
+	a.recording, a.record = "", nil
+}
+
+// Play writes the output recorded into the macro name,
+// unknown names are ignored
+func (a *Ansi) Play(name string) {
+	a.wmu.Lock()
+	m, ok := a.macros[name]
+	a.wmu.Unlock()
+	if ok {
+		a.Write(m)
+	}
+}
@@ -0,0 +1,28 @@
+package ansi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMacro(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Record("box")
+	a.Set(Blue)
+	a.Write([]byte("+--+\n|  |\n+--+\n"))
+	a.Set(Reset)
+	a.StopRecord()
+	a.Write([]byte("after"))
+	box := "\x1b[34m+--+\n|  |\n+--+\n\x1b[0m"
+	if got := f.written(); got != box+"after" {
+		t.Fatalf("expected the recording to be written, got %q", got)
+	}
+	a.Play("box")
+	a.Play("box")
+	a.Play("missing")
+	if got, want := f.written(), box+"after"+strings.Repeat(box, 2); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
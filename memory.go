@@ -0,0 +1,49 @@
+package ansi
+
+import (
+	"strconv"
+	"time"
+)
+
+// Query Macro Space		<ESC>[?62n
+// Report Macro Space		<ESC>[{n}*{
+// Query Memory Checksum	<ESC>[?63;{id}n
+// Report Memory Checksum	<ESC>P{id}!~{hex}<ESC>\
+// These are answered by DEC terminals (VT420 and later) and
+// their emulators, most others ignore them.
+var QueryMacroSpace = []byte{Esc, '[', '?', '6', '2', 'n'}
+
+func QueryChecksum(id int) []byte {
+	b := append([]byte{Esc, '[', '?', '6', '3', ';'}, strconv.Itoa(id)...)
+	return append(b, 'n')
+}
+
+// MacroSpace asks the terminal how much space is free for macros,
+// in the units it reports them in, and waits for the answer
+func (a *Ansi) MacroSpace(timeout time.Duration) (int, error) {
+	r, err := a.query(QueryMacroSpace, isType(MacroSpace), timeout)
+	if err != nil {
+		return 0, err
+	}
+	return r.Code, nil
+}
+
+// MemoryChecksum asks the terminal for the checksum of its
+// memory, such as the macros, and waits for the hex digits.
+// id tags the query and is echoed in the answer.
+func (a *Ansi) MemoryChecksum(id int, timeout time.Duration) (string, error) {
+	r, err := a.query(QueryChecksum(id), func(r *Report) bool {
+		return r.Type == Checksum && r.Code == id
+	}, timeout)
+	if err != nil {
+		return "", err
+	}
+	return r.Text, nil
+}
+
+// parseChecksum is the report of a DECCKSR answer
+func (a *Ansi) parseChecksum(id, hex string) *Report {
+	r := &Report{Type: Checksum, Text: hex}
+	r.Code, _ = strconv.Atoi(id)
+	return r
+}
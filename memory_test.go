@@ -0,0 +1,55 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMacroSpace(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == "\x1b[?62n" {
+			return "\x1b[128*{"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	n, err := a.MacroSpace(time.Second)
+	if err != nil || n != 128 {
+		t.Fatalf("unexpected %d %v", n, err)
+	}
+}
+
+func TestMemoryChecksum(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == "\x1b[?63;7n" {
+			return "\x1bP3!~0000\x1b\\\x1bP7!~1A2B\x1b\\"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	sum, err := a.MemoryChecksum(7, time.Second)
+	if err != nil || sum != "1A2B" {
+		t.Fatalf("unexpected %q %v", sum, err)
+	}
+	if r := <-a.Reports; r.Type != Checksum || r.Code != 3 {
+		t.Fatalf("expected the other checksum to be queued, got %+v", r)
+	}
+}
+
+func TestMacroSpaceReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("a\x1b[16*{n")
+	if r := <-a.Reports; r.Type != MacroSpace || r.Code != 16 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "an" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
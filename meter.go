@@ -0,0 +1,30 @@
+package ansi
+
+import (
+	"math"
+	"strings"
+)
+
+// Meter renders value out of max as a horizontal meter width cells
+// wide, the filled cells in the filled style followed by the rest
+// in the empty style. value is clamped to [0, max], a max of zero
+// or less renders an empty meter.
+func Meter(value, max float64, width int, filled, empty Attribute) []byte {
+	if width <= 0 {
+		return nil
+	}
+	n := 0
+	if max > 0 && value > 0 {
+		n = int(math.Round(math.Min(value, max) / max * float64(width)))
+	}
+	var b []byte
+	if n > 0 {
+		b = AppendSet(b, filled)
+		b = append(b, strings.Repeat("█", n)...)
+	}
+	if n < width {
+		b = AppendSet(b, empty)
+		b = append(b, strings.Repeat("░", width-n)...)
+	}
+	return AppendSet(b, Reset)
+}
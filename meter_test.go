@@ -0,0 +1,32 @@
+package ansi
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMeter(t *testing.T) {
+	fill := func(n int) string { return string(Set(Green)) + strings.Repeat("█", n) }
+	empty := func(n int) string { return string(Set(Black)) + strings.Repeat("░", n) }
+	reset := string(Set(Reset))
+	for _, tc := range []struct {
+		value, max float64
+		out        string
+	}{
+		{0, 10, empty(5) + reset},
+		{4, 10, fill(2) + empty(3) + reset},
+		{10, 10, fill(5) + reset},
+		{20, 10, fill(5) + reset},
+		{-1, 10, empty(5) + reset},
+		{5, 0, empty(5) + reset},
+		{math.NaN(), 10, empty(5) + reset},
+	} {
+		if got := string(Meter(tc.value, tc.max, 5, Green, Black)); got != tc.out {
+			t.Errorf("Meter(%v, %v) = %q, expected %q", tc.value, tc.max, got, tc.out)
+		}
+	}
+	if b := Meter(1, 1, 0, Green, Black); b != nil {
+		t.Errorf("expected nothing for zero width, got %q", b)
+	}
+}
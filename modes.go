@@ -0,0 +1,73 @@
+package ansi
+
+import "strings"
+
+// ModeGuard records the modes changed through an Ansi
+// (<ESC>[{mode}h and l, including private ? modes) so
+// they can all be changed back
+type ModeGuard struct {
+	a *Ansi
+	//the modes in the order changed, and whether
+	//each was on before
+	modes  []string
+	before map[string]bool
+}
+
+// ModeGuard starts recording the modes changed by
+// all writes, until Restore is called
+func (a *Ansi) ModeGuard() *ModeGuard {
+	g := &ModeGuard{a: a, before: map[string]bool{}}
+	a.wmu.Lock()
+	a.guards = append(a.guards, g)
+	a.wmu.Unlock()
+	return g
+}
+
+// changed notes the modes in params were set on
+// or off, the first change of each mode implies
+// its original state. wmu is held.
+func (g *ModeGuard) changed(params string, on bool) {
+	private := strings.HasPrefix(params, "?")
+	body := strings.TrimPrefix(params, "?")
+	for i := 0; i >= 0; {
+		var m string
+		m, i = field(body, i)
+		if private {
+			m = "?" + m
+		}
+		if _, ok := g.before[m]; !ok {
+			//a copy, so the written bytes are not kept
+			key := string([]byte(m))
+			g.before[key] = !on
+			g.modes = append(g.modes, key)
+		}
+	}
+}
+
+// Restore stops recording and changes every recorded
+// mode back to its original state, the last changed first
+func (g *ModeGuard) Restore() {
+	a := g.a
+	a.wmu.Lock()
+	for i, o := range a.guards {
+		if o == g {
+			a.guards = append(a.guards[:i], a.guards[i+1:]...)
+			break
+		}
+	}
+	var b []byte
+	for i := len(g.modes) - 1; i >= 0; i-- {
+		m := g.modes[i]
+		b = append(append(b, Esc, '['), m...)
+		if g.before[m] {
+			b = append(b, 'h')
+		} else {
+			b = append(b, 'l')
+		}
+	}
+	g.modes, g.before = nil, map[string]bool{}
+	a.wmu.Unlock()
+	if len(b) > 0 {
+		a.Write(b)
+	}
+}
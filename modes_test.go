@@ -0,0 +1,28 @@
+package ansi
+
+import "testing"
+
+func TestModeGuard(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	g := a.ModeGuard()
+	a.Write([]byte("\x1b[?1049h"))
+	a.EnableMouseTracking()
+	a.EnableSGRMouse()
+	a.CursorHide()
+	a.CursorShow()
+	written := len(f.written())
+	g.Restore()
+	want := "\x1b[?25h\x1b[?1006l\x1b[?1000l\x1b[?1049l"
+	if got := f.written()[written:]; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	//no longer recording
+	a.EnableSGRMouse()
+	written = len(f.written())
+	g.Restore()
+	if got := f.written()[written:]; got != "" {
+		t.Fatalf("expected nothing, got %q", got)
+	}
+}
@@ -0,0 +1,167 @@
+package ansi
+
+// Enable X10 Mouse		<ESC>[?9h
+// Enable Mouse Tracking		<ESC>[?1000h
+// Enable Button Event Tracking	<ESC>[?1002h
+// Enable Any Event Tracking	<ESC>[?1003h
+// Enable SGR Mouse Coordinates	<ESC>[?1006h
+// Enable URXVT Mouse Coordinates	<ESC>[?1015h
+// Report Mouse			<ESC>[M{button}{COLUMN}{ROW}
+// Report SGR Mouse		<ESC>[<{button};{COLUMN};{ROW}{M|m}
+// Report URXVT Mouse		<ESC>[{button};{COLUMN};{ROW}M
+// Each mode is disabled with l in place of h. The tracking modes
+// pick which events are reported, the coordinate modes how. The
+// default form is limited to column and row 95 here, since larger
+// positions are sent as non-ASCII bytes, so enable the SGR form
+// alongside a tracking mode.
+var EnableX10Mouse = []byte{Esc, '[', '?', '9', 'h'}
+var DisableX10Mouse = []byte{Esc, '[', '?', '9', 'l'}
+var EnableMouseTracking = []byte{Esc, '[', '?', '1', '0', '0', '0', 'h'}
+var DisableMouseTracking = []byte{Esc, '[', '?', '1', '0', '0', '0', 'l'}
+var EnableButtonTracking = []byte{Esc, '[', '?', '1', '0', '0', '2', 'h'}
+var DisableButtonTracking = []byte{Esc, '[', '?', '1', '0', '0', '2', 'l'}
+var EnableAnyEventTracking = []byte{Esc, '[', '?', '1', '0', '0', '3', 'h'}
+var DisableAnyEventTracking = []byte{Esc, '[', '?', '1', '0', '0', '3', 'l'}
+var EnableSGRMouse = []byte{Esc, '[', '?', '1', '0', '0', '6', 'h'}
+var DisableSGRMouse = []byte{Esc, '[', '?', '1', '0', '0', '6', 'l'}
+var EnableURXVTMouse = []byte{Esc, '[', '?', '1', '0', '1', '5', 'h'}
+var DisableURXVTMouse = []byte{Esc, '[', '?', '1', '0', '1', '5', 'l'}
+
+func (a *Ansi) EnableX10Mouse() {
+	a.Write(EnableX10Mouse)
+}
+
+func (a *Ansi) DisableX10Mouse() {
+	a.Write(DisableX10Mouse)
+}
+
+func (a *Ansi) EnableMouseTracking() {
+	a.Write(EnableMouseTracking)
+}
+
+func (a *Ansi) DisableMouseTracking() {
+	a.Write(DisableMouseTracking)
+}
+
+func (a *Ansi) EnableButtonTracking() {
+	a.Write(EnableButtonTracking)
+}
+
+func (a *Ansi) DisableButtonTracking() {
+	a.Write(DisableButtonTracking)
+}
+
+func (a *Ansi) EnableAnyEventTracking() {
+	a.Write(EnableAnyEventTracking)
+}
+
+func (a *Ansi) DisableAnyEventTracking() {
+	a.Write(DisableAnyEventTracking)
+}
+
+func (a *Ansi) EnableSGRMouse() {
+	a.Write(EnableSGRMouse)
+}
+
+func (a *Ansi) DisableSGRMouse() {
+	a.Write(DisableSGRMouse)
+}
+
+func (a *Ansi) EnableURXVTMouse() {
+	a.Write(EnableURXVTMouse)
+}
+
+func (a *Ansi) DisableURXVTMouse() {
+	a.Write(DisableURXVTMouse)
+}
+
+// parseMouse is the report of an SGR mouse event,
+// the body holds the button, column and row
+func (a *Ansi) parseMouse(body string, press bool) *Report {
+	p := params(body)
+	r := &Report{Type: Mouse, Code: p[0], Press: press}
+	r.Pos.Col, r.Pos.Row = p[1], p[2]
+	return r
+}
+
+// parseURXVTMouse is the report of a URXVT mouse event, the body
+// holds the button (offset by 32 as in the default form), column and row
+func (a *Ansi) parseURXVTMouse(body string) *Report {
+	p := params(body)
+	b := p[0] - 32
+	r := &Report{Type: Mouse, Code: b, Press: b&3 != 3 || b&64 != 0}
+	r.Pos.Col, r.Pos.Row = p[1], p[2]
+	return r
+}
+
+// parseX10Mouse is the report of a mouse event in the default
+// form, three bytes each offset by 32. Releases do not say which
+// button was released.
+func (a *Ansi) parseX10Mouse(body []byte) *Report {
+	b := int(body[0]) - 32
+	r := &Report{Type: Mouse, Code: b, Press: b&3 != 3 || b&64 != 0}
+	r.Pos.Col, r.Pos.Row = int(body[1])-32, int(body[2])-32
+	return r
+}
+
+// Mouse buttons, the Button of a MouseEvent
+const (
+	MouseLeft      = 0
+	MouseMiddle    = 1
+	MouseRight     = 2
+	MouseRelease   = 3
+	MouseWheelUp   = 64
+	MouseWheelDown = 65
+)
+
+// MouseEvent is a mouse report decoded
+type MouseEvent struct {
+	//Button is one of the Mouse buttons
+	Button   int
+	Row, Col int
+	//Pressed is false for releases
+	Pressed bool
+	//Motion is true when the mouse moved
+	Motion bool
+	Mods   Modifier
+}
+
+// MouseEvent decodes a Mouse report
+func (r *Report) MouseEvent() MouseEvent {
+	b := r.Code
+	e := MouseEvent{
+		Button:  b &^ (4 | 8 | 16 | 32),
+		Row:     r.Pos.Row,
+		Col:     r.Pos.Col,
+		Pressed: r.Press,
+		Motion:  b&32 != 0,
+	}
+	if b&4 != 0 {
+		e.Mods |= ModShift
+	}
+	if b&8 != 0 {
+		e.Mods |= ModAlt
+	}
+	if b&16 != 0 {
+		e.Mods |= ModCtrl
+	}
+	return e
+}
+
+// MouseEvents returns a channel of the mouse events read, which is
+// closed once reading stops. Once called, mouse reports are sent
+// here instead of Reports (though queries still receive theirs).
+// Like Reports, it holds the latest ReportsBuffer events.
+func (a *Ansi) MouseEvents() <-chan MouseEvent {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.mice == nil {
+		a.mice = make(chan MouseEvent, ReportsBuffer)
+		select {
+		case <-a.done:
+			close(a.mice)
+		default:
+		}
+	}
+	return a.mice
+}
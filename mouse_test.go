@@ -0,0 +1,88 @@
+package ansi
+
+import "testing"
+
+func TestMouseModes(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableMouseTracking()
+	a.EnableButtonTracking()
+	a.EnableAnyEventTracking()
+	a.EnableSGRMouse()
+	a.DisableSGRMouse()
+	a.DisableAnyEventTracking()
+	a.DisableButtonTracking()
+	a.DisableMouseTracking()
+	want := "\x1b[?1000h\x1b[?1002h\x1b[?1003h\x1b[?1006h" +
+		"\x1b[?1006l\x1b[?1003l\x1b[?1002l\x1b[?1000l"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestMouseReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go f.send("a\x1b[<0;12;5Mb\x1b[<0;12;5m")
+	for _, press := range []bool{true, false} {
+		r := <-a.Reports
+		if r.Type != Mouse || r.Code != 0 || r.Pos.Col != 12 || r.Pos.Row != 5 || r.Press != press {
+			t.Fatalf("unexpected report %+v", r)
+		}
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "ab" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestMouseEncodings(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableX10Mouse()
+	a.EnableURXVTMouse()
+	a.DisableURXVTMouse()
+	a.DisableX10Mouse()
+	if got, want := f.written(), "\x1b[?9h\x1b[?1015h\x1b[?1015l\x1b[?9l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	mice := a.MouseEvents()
+	go f.send("a\x1b[M\x22\x2c\x25b\x1b[M\x23\x2c\x25\x1b[66;7;3Mc\x1b[<20;1;2M\x1b[<64;1;2M")
+	for _, want := range []MouseEvent{
+		{Button: MouseRight, Col: 12, Row: 5, Pressed: true},
+		{Button: MouseRelease, Col: 12, Row: 5},
+		{Button: MouseRight, Col: 7, Row: 3, Pressed: true, Motion: true},
+		{Button: MouseLeft, Col: 1, Row: 2, Pressed: true, Mods: ModShift | ModCtrl},
+		{Button: MouseWheelUp, Col: 1, Row: 2, Pressed: true},
+	} {
+		if e := <-mice; e != want {
+			t.Fatalf("got %+v, expected %+v", e, want)
+		}
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "abc" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestMouseEventsClosed(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	mice := a.MouseEvents()
+	f.w.Close()
+	if _, open := <-mice; open {
+		t.Fatal("expected the events closed once reading stops")
+	}
+	if _, open := <-a.MouseEvents(); open {
+		t.Fatal("expected the events to stay closed")
+	}
+	var w writeLog
+	b := Wrap(&w)
+	<-b.Done()
+	if _, open := <-b.MouseEvents(); open {
+		t.Fatal("expected the events closed when called after reading stops")
+	}
+}
@@ -0,0 +1,132 @@
+package ansi
+
+import "strconv"
+
+// Point is a screen position, counting from 1 like Goto
+type Point struct {
+	Row, Col int
+}
+
+// MoveBetween returns the shortest sequence moving the cursor from
+// one point to another: relative moves, a carriage return followed
+// by relative moves, or an absolute Goto
+func MoveBetween(from, to Point) []byte {
+	rel := appendMove(nil, to.Row-from.Row, 'B', 'A')
+	cr := append([]byte{'\r'}, rel...)
+	rel = appendMove(rel, to.Col-from.Col, 'C', 'D')
+	cr = appendMove(cr, to.Col-1, 'C', 'D')
+	best := rel
+	if len(cr) < len(best) {
+		best = cr
+	}
+	if abs := AppendGoto(nil, uint16(to.Row), uint16(to.Col)); len(abs) < len(best) {
+		best = abs
+	}
+	return best
+}
+
+// Up, Down, Forward and Backward move the cursor n rows or columns,
+// stopping at the edge of the screen. A count of 0 is no move, so
+// the builders return nothing and the methods write nothing.
+func Up(n uint16) []byte {
+	return appendMove(nil, -int(n), 'B', 'A')
+}
+
+func Down(n uint16) []byte {
+	return appendMove(nil, int(n), 'B', 'A')
+}
+
+func Forward(n uint16) []byte {
+	return appendMove(nil, int(n), 'C', 'D')
+}
+
+func Backward(n uint16) []byte {
+	return appendMove(nil, -int(n), 'C', 'D')
+}
+
+func (a *Ansi) Up(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendMove(dst, -int(n), 'B', 'A') })
+}
+
+func (a *Ansi) Down(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendMove(dst, int(n), 'B', 'A') })
+}
+
+func (a *Ansi) Forward(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendMove(dst, int(n), 'C', 'D') })
+}
+
+func (a *Ansi) Backward(n uint16) {
+	a.writeAppend(func(dst []byte) []byte { return appendMove(dst, -int(n), 'C', 'D') })
+}
+
+// CursorUp, CursorDown, CursorForward and
+// CursorBackward are Up, Down, Forward and Backward
+func CursorUp(n uint16) []byte {
+	return Up(n)
+}
+
+func CursorDown(n uint16) []byte {
+	return Down(n)
+}
+
+func CursorForward(n uint16) []byte {
+	return Forward(n)
+}
+
+func CursorBackward(n uint16) []byte {
+	return Backward(n)
+}
+
+func (a *Ansi) CursorUp(n uint16) {
+	a.Up(n)
+}
+
+func (a *Ansi) CursorDown(n uint16) {
+	a.Down(n)
+}
+
+func (a *Ansi) CursorForward(n uint16) {
+	a.Forward(n)
+}
+
+func (a *Ansi) CursorBackward(n uint16) {
+	a.Backward(n)
+}
+
+// Cursor Home		<ESC>[H
+// CursorSave and CursorRestore are SaveCursor and RestoreCursor
+var CursorHome = []byte{Esc, '[', 'H'}
+var CursorSave = SaveCursor
+var CursorRestore = RestoreCursor
+
+func (a *Ansi) CursorHome() {
+	a.Write(CursorHome)
+}
+
+func (a *Ansi) CursorSave() {
+	a.Write(CursorSave)
+}
+
+func (a *Ansi) CursorRestore() {
+	a.Write(CursorRestore)
+}
+
+// appendMove appends a relative move of n, using the
+// forward final when n is positive, otherwise the back
+// final. A count of 1 is left out as it is the default.
+func appendMove(dst []byte, n int, forward, back byte) []byte {
+	final := forward
+	if n < 0 {
+		n, final = -n, back
+	}
+	switch n {
+	case 0:
+		return dst
+	case 1:
+		return append(dst, Esc, '[', final)
+	}
+	dst = append(dst, Esc, '[')
+	dst = strconv.AppendInt(dst, int64(n), 10)
+	return append(dst, final)
+}
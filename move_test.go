@@ -0,0 +1,72 @@
+package ansi
+
+import "testing"
+
+func TestMoveBetween(t *testing.T) {
+	for _, tc := range []struct {
+		from, to Point
+		out      string
+	}{
+		{Point{5, 5}, Point{5, 5}, ""},
+		{Point{5, 5}, Point{5, 6}, "\x1b[C"},
+		{Point{5, 5}, Point{4, 4}, "\x1b[A\x1b[D"},
+		{Point{5, 5}, Point{4, 2}, "\x1b[4;2H"},
+		{Point{5, 40}, Point{6, 1}, "\r\x1b[B"},
+		{Point{5, 40}, Point{5, 2}, "\r\x1b[C"},
+		{Point{1, 1}, Point{200, 100}, "\x1b[200;100H"},
+	} {
+		got := MoveBetween(tc.from, tc.to)
+		if string(got) != tc.out {
+			t.Errorf("MoveBetween(%v, %v) = %q, expected %q", tc.from, tc.to, got, tc.out)
+		}
+		if abs := Goto(uint16(tc.to.Row), uint16(tc.to.Col)); len(got) > len(abs) {
+			t.Errorf("MoveBetween(%v, %v) is longer than %q", tc.from, tc.to, abs)
+		}
+	}
+}
+
+func TestMoves(t *testing.T) {
+	for _, tc := range []struct {
+		got, want string
+	}{
+		{string(Up(3)), "\x1b[3A"},
+		{string(Down(1)), "\x1b[B"},
+		{string(Forward(12)), "\x1b[12C"},
+		{string(Backward(2)), "\x1b[2D"},
+		{string(Up(0)), ""},
+	} {
+		if tc.got != tc.want {
+			t.Errorf("got %q, expected %q", tc.got, tc.want)
+		}
+	}
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Up(1)
+	a.Down(0)
+	a.Forward(4)
+	a.Backward(0)
+	if got := f.written(); got != "\x1b[A\x1b[4C" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestCursorMoves(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.CursorSave()
+	a.CursorUp(2)
+	a.CursorDown(1)
+	a.CursorForward(3)
+	a.CursorBackward(0)
+	a.CursorBackward(4)
+	a.CursorHome()
+	a.CursorRestore()
+	if got, want := f.written(), "\x1b[s\x1b[2A\x1b[B\x1b[3C\x1b[4D\x1b[H\x1b[u"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if got := string(CursorUp(5)) + string(CursorDown(5)) + string(CursorForward(5)) + string(CursorBackward(5)); got != "\x1b[5A\x1b[5B\x1b[5C\x1b[5D" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+package ansi
+
+import "strings"
+
+// Desktop Notification	<ESC>]9;{message}<ESC>\
+func Notification(message string) []byte {
+	b := append([]byte{Esc, ']', '9', ';'}, stripControls(message)...)
+	return append(b, Esc, '\\')
+}
+
+// Notify asks the terminal to show a desktop notification,
+// control characters are removed from the message so it
+// cannot end the sequence early
+func (a *Ansi) Notify(message string) {
+	a.Write(Notification(message))
+}
+
+// stripControls removes C0 and C1 control characters
+func stripControls(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r >= 0x7f && r <= 0x9f {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,13 @@
+package ansi
+
+import "testing"
+
+func TestNotify(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Notify("build\x1b\\ done\a\u009c!")
+	if got, want := f.written(), "\x1b]9;build\\ done!\x1b\\"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+package ansi
+
+// NoWrap turns line wrap off while fn renders, so long lines are
+// cut off rather than disturbing the layout. Wrap is turned back
+// on afterwards, unless it was already off beforehand.
+func (a *Ansi) NoWrap(fn func()) {
+	a.wmu.Lock()
+	off := a.nowrap
+	a.wmu.Unlock()
+	if off {
+		fn()
+		return
+	}
+	a.DisableLineWrap()
+	defer a.EnableLineWrap()
+	fn()
+}
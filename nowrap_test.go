@@ -0,0 +1,20 @@
+package ansi
+
+import "testing"
+
+func TestNoWrap(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.NoWrap(func() {
+		a.Write([]byte("long line"))
+		//already off, left alone
+		a.NoWrap(func() {
+			a.Write([]byte("!"))
+		})
+	})
+	want := "\x1b[?7l" + "long line!" + "\x1b[?7h"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
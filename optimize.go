@@ -0,0 +1,99 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Optimize removes sequences from b which provably have no
+// effect: an SGR set whose attributes are all overridden by
+// the SGR set directly after it. Opposite cursor moves are
+// kept, since a terminal stops the first at its margins.
+func Optimize(b []byte) []byte {
+	var out []string
+	s := string(b)
+	for i := 0; i < len(s); {
+		n := 1
+		if s[i] == Esc {
+			n, _ = sequenceLen(s[i:])
+		} else {
+			for i+n < len(s) && s[i+n] != Esc {
+				n++
+			}
+		}
+		tok := s[i : i+n]
+		i += n
+		for len(out) > 0 && overrides(tok, out[len(out)-1]) {
+			out = out[:len(out)-1]
+		}
+		out = append(out, tok)
+	}
+	return []byte(strings.Join(out, ""))
+}
+
+// overrides is true when the SGR sequence next
+// replaces every attribute set by the SGR sequence prev
+func overrides(next, prev string) bool {
+	np, n := sgrParams(next)
+	pp, p := sgrParams(prev)
+	if n == 0 || p == 0 {
+		return false
+	}
+	nattrs := splitSGR(np)
+	if nattrs[0] == string(Reset) {
+		return true
+	}
+	slots := map[string]bool{}
+	for _, a := range nattrs {
+		slots[sgrSlot(a)] = true
+	}
+	for _, a := range splitSGR(pp) {
+		if s := sgrSlot(a); s == "" || !slots[s] {
+			return false
+		}
+	}
+	return true
+}
+
+// sgrSlot returns which color an attribute
+// sets, or "" for anything else
+func sgrSlot(a string) string {
+	n, err := strconv.Atoi(strings.SplitN(a, ";", 2)[0])
+	switch {
+	case err != nil:
+		return ""
+	case n >= 30 && n <= 39, n >= 90 && n <= 97:
+		return "fg"
+	case n >= 40 && n <= 49, n >= 100 && n <= 107:
+		return "bg"
+	}
+	return ""
+}
+
+// splitSGR splits SGR parameters into attributes,
+// keeping extended colors (38;5;n and 38;2;r;g;b)
+// together. Empty parameters are resets.
+func splitSGR(params string) []string {
+	fields := strings.Split(params, ";")
+	var attrs []string
+	for i := 0; i < len(fields); i++ {
+		f := fields[i]
+		if f == "" {
+			f = string(Reset)
+		}
+		n := 0
+		if f == "38" || f == "48" || f == "58" {
+			if i+1 < len(fields) && fields[i+1] == "5" {
+				n = 2
+			} else if i+1 < len(fields) && fields[i+1] == "2" {
+				n = 4
+			}
+			if i+n >= len(fields) {
+				n = len(fields) - 1 - i
+			}
+		}
+		attrs = append(attrs, strings.Join(fields[i:i+n+1], ";"))
+		i += n
+	}
+	return attrs
+}
@@ -0,0 +1,26 @@
+package ansi
+
+import "testing"
+
+func TestOptimize(t *testing.T) {
+	up, down := "\x1b[3A", "\x1b[3B"
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"plain", "plain"},
+		//overridden sets
+		{string(Set(Red)) + string(Set(Green)) + "x", string(Set(Green)) + "x"},
+		{string(Set(Red, BlueBG)) + string(Set(Reset)) + "x", string(Set(Reset)) + "x"},
+		{string(Set(Red)) + string(Set(Attribute("38;5;200"))) + "x", string(Set(Attribute("38;5;200"))) + "x"},
+		//partially overridden sets are kept
+		{string(Set(Bright, Red)) + string(Set(Green)) + "x", string(Set(Bright, Red)) + string(Set(Green)) + "x"},
+		{string(Set(Red)) + "x" + string(Set(Green)), string(Set(Red)) + "x" + string(Set(Green))},
+		//opposite moves are kept, the first may stop at a margin
+		{"a" + up + down + "b", "a" + up + down + "b"},
+		{"\x1b[C\x1b[1D", "\x1b[C\x1b[1D"},
+	} {
+		if got := string(Optimize([]byte(tc.in))); got != tc.out {
+			t.Errorf("Optimize(%q) = %q, expected %q", tc.in, got, tc.out)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ansi
+
+import "io"
+
+// Overflow is what happens to a report when the Reports queue is full
+type Overflow int
+
+const (
+	//DropOldest makes way for the report by
+	//dropping the oldest one queued
+	DropOldest Overflow = iota
+	//DropNewest drops the report
+	DropNewest
+	//Block holds up reading until the report is
+	//taken from the queue, or the Ansi is closed
+	Block
+)
+
+// Malformed is what happens to a malformed report, like a
+// position missing its column (<ESC>[12R)
+type Malformed int
+
+const (
+	//DropMalformed drops the sequence
+	DropMalformed Malformed = iota
+	//PassMalformed passes the sequence on as data
+	PassMalformed
+	//ReportMalformed reports the sequence as an
+	//Invalid report, with the sequence as its Text
+	ReportMalformed
+)
+
+// Options configure WrapWith
+type Options struct {
+	//ReportsBuffer is the capacity of the Reports
+	//queue, the ReportsBuffer constant when 0
+	ReportsBuffer int
+	//Overflow is what happens when the queue is full
+	Overflow Overflow
+	//OnReport, when set, is called with each report
+	//instead of it being queued, see Ansi.OnReport
+	OnReport func(*Report)
+	//Malformed is what happens to malformed reports
+	Malformed Malformed
+}
+
+// WrapWith is Wrap configured by opts
+func WrapWith(rw io.ReadWriter, opts Options) *Ansi {
+	size := opts.ReportsBuffer
+	if size <= 0 {
+		size = ReportsBuffer
+	}
+	a := newAnsi(rw, size)
+	a.overflow = opts.Overflow
+	a.onReport = opts.OnReport
+	a.malformedPolicy = opts.Malformed
+	go a.read()
+	return a
+}
+
+// malformed handles the malformed report seq as the policy
+// says, returning dst with seq added when passed on as data,
+// or an Invalid report when reported
+func (a *Ansi) malformed(dst, seq []byte) ([]byte, *Report) {
+	switch a.malformedPolicy {
+	case PassMalformed:
+		return append(dst, seq...), nil
+	case ReportMalformed:
+		return dst, &Report{Type: Invalid, Text: string(seq)}
+	}
+	return dst, nil
+}
+
+// OnReport sets fn to be called with each report which would
+// otherwise be queued on Reports, nil goes back to queueing.
+// fn is called by the reader, so reading waits for it to return.
+func (a *Ansi) OnReport(fn func(*Report)) {
+	a.mu.Lock()
+	a.onReport = fn
+	a.mu.Unlock()
+}
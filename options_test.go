@@ -0,0 +1,78 @@
+package ansi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWrapWithDropNewest(t *testing.T) {
+	f := newFakeTerm()
+	a := WrapWith(f, Options{ReportsBuffer: 2, Overflow: DropNewest})
+	defer a.Close()
+	go func() {
+		for i := 0; i < 5; i++ {
+			f.send(fmt.Sprintf("\x1b[%d;1R", i+1))
+		}
+		f.send("data")
+	}()
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "data" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	if cap(a.Reports) != 2 || len(a.Reports) != 2 {
+		t.Fatalf("expected 2 of 2 queued, got %d of %d", len(a.Reports), cap(a.Reports))
+	}
+	if r := <-a.Reports; r.Pos.Row != 1 {
+		t.Fatalf("expected the first report kept, got %+v", r)
+	}
+}
+
+func TestWrapWithBlock(t *testing.T) {
+	f := newFakeTerm()
+	a := WrapWith(f, Options{ReportsBuffer: 1, Overflow: Block})
+	go func() {
+		f.send("\x1b[1;1R")
+		f.send("\x1b[2;1R")
+		f.send("data")
+	}()
+	read := make(chan string)
+	go func() {
+		buf := make([]byte, 8)
+		n, _ := a.Read(buf)
+		read <- string(buf[:n])
+	}()
+	select {
+	case s := <-read:
+		t.Fatalf("expected the read to wait for the queue, got %q", s)
+	case <-time.After(20 * time.Millisecond):
+	}
+	for row := 1; row <= 2; row++ {
+		if r := <-a.Reports; r.Pos.Row != row {
+			t.Fatalf("unexpected report %+v", r)
+		}
+	}
+	if s := <-read; s != "data" {
+		t.Fatalf("unexpected data %q", s)
+	}
+	a.Close()
+}
+
+func TestOnReport(t *testing.T) {
+	f := newFakeTerm()
+	got := make(chan *Report, 4)
+	a := WrapWith(f, Options{OnReport: func(r *Report) { got <- r }})
+	defer a.Close()
+	f.send("\x1b[3;4R")
+	if r := <-got; r.Type != Position || r.Pos.Row != 3 || r.Pos.Col != 4 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	a.OnReport(nil)
+	f.send("\x1b[5;6R")
+	if r := <-a.Reports; r.Pos.Row != 5 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if len(got) != 0 {
+		t.Fatal("expected OnReport to be unset")
+	}
+}
@@ -0,0 +1,27 @@
+package ansi
+
+// Parse extracts the reports from b, as a wrapped reader would,
+// returning them along with the remaining data. It is safe on
+// any input and runs in time linear in len(b).
+func Parse(b []byte) ([]Report, []byte) {
+	return parseWith(b, DropMalformed)
+}
+
+// parseWith is Parse handling malformed reports by policy
+func parseWith(b []byte, policy Malformed) ([]Report, []byte) {
+	termMu.Lock()
+	re := reportCode
+	termMu.Unlock()
+	a := &Ansi{reportCode: re, malformedPolicy: policy}
+	var reports []Report
+	var data []byte
+	for _, e := range a.scan(b) {
+		switch e := e.(type) {
+		case ReportEvent:
+			reports = append(reports, *e.Report)
+		case DataEvent:
+			data = append(data, e.Bytes...)
+		}
+	}
+	return reports, data
+}
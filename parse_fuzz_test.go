@@ -0,0 +1,63 @@
+//go:build go1.18
+// +build go1.18
+
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	for _, s := range []string{
+		"plain",
+		"\x1b[5;7R\x1b[0n\x1b[3n\x1b[?10n",
+		"\x1b[R\x1b[5R\x1b[;R",
+		"\x1b[8;24;80t\x1b[t",
+		"\x1bP1+r544e=78\x1b\\\x1bP0+r\x1b\\",
+		"\x1b]11;rgb:ffff/0/0\a\x1b]10;\x1b\\",
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		_, data := Parse(b)
+		if len(data) > len(b) {
+			t.Fatalf("data %q is longer than the input %q", data, b)
+		}
+		//plain input passes through untouched
+		if bytes.IndexByte(b, Esc) < 0 && !bytes.Equal(data, b) {
+			t.Fatalf("got %q, expected %q", data, b)
+		}
+	})
+}
+
+func FuzzParseMalformed(f *testing.F) {
+	for _, s := range []string{
+		"\x1b[12R\x1b[;R\x1b[5;7R",
+		"\x1b[1;2;3$y\x1b[?25;1$y\x1b[2I\x1b[O",
+		"\x1b[1:2*{\x1b[12*{\x1b[9n",
+	} {
+		f.Add([]byte(s))
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		_, dropped := parseWith(b, DropMalformed)
+		_, passed := parseWith(b, PassMalformed)
+		reports, reported := parseWith(b, ReportMalformed)
+		if !bytes.Equal(dropped, reported) {
+			t.Fatalf("reporting changed the data %q to %q", dropped, reported)
+		}
+		//the malformed sequences are what passing adds
+		n := 0
+		for _, r := range reports {
+			if r.Type == Invalid {
+				if !bytes.Contains(b, []byte(r.Text)) {
+					t.Fatalf("invalid report %q is not in the input %q", r.Text, b)
+				}
+				n += len(r.Text)
+			}
+		}
+		if len(passed) != len(dropped)+n {
+			t.Fatalf("passed %q, dropped %q and %d invalid bytes", passed, dropped, n)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package ansi
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	reports, data := Parse([]byte("a\x1b[0nb\x1b[5;7Rc\x1b]11;rgb:0/0/0\a\x1b[R"))
+	if string(data) != "abc" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	if len(reports) != 3 || reports[0].Type != OK || reports[1].Pos.Row != 5 ||
+		reports[2].Type != OSC || reports[2].Code != 11 {
+		t.Fatalf("unexpected reports %+v", reports)
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	in := []byte("a\x1b[12Rb\x1b[5;7R\x1b[1:2*{c\x1b[9n")
+	if _, data := parseWith(in, DropMalformed); string(data) != "abc" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	if _, data := parseWith(in, PassMalformed); string(data) != "a\x1b[12Rb\x1b[1:2*{c\x1b[9n" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	reports, data := parseWith(in, ReportMalformed)
+	if string(data) != "abc" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	var invalid []string
+	for _, r := range reports {
+		if r.Type == Invalid {
+			invalid = append(invalid, r.Text)
+		}
+	}
+	if len(reports) != 4 || len(invalid) != 3 || invalid[0] != "\x1b[12R" || invalid[1] != "\x1b[1:2*{" || invalid[2] != "\x1b[9n" {
+		t.Fatalf("unexpected reports %+v", reports)
+	}
+}
+
+func TestWrapWithMalformed(t *testing.T) {
+	f := newFakeTerm()
+	a := WrapWith(f, Options{Malformed: ReportMalformed})
+	defer a.Close()
+	f.send("\x1b[;3R")
+	if r := <-a.Reports; r.Type != Invalid || r.Text != "\x1b[;3R" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
@@ -0,0 +1,69 @@
+package ansi
+
+import "bytes"
+
+// Bracketed Paste Start	<ESC>[200~
+// Bracketed Paste End		<ESC>[201~
+var PasteStart = []byte{Esc, '[', '2', '0', '0', '~'}
+var PasteEnd = []byte{Esc, '[', '2', '0', '1', '~'}
+
+// Enable Bracketed Paste	<ESC>[?2004h
+// Disable Bracketed Paste	<ESC>[?2004l
+var EnableBracketedPaste = []byte{Esc, '[', '?', '2', '0', '0', '4', 'h'}
+var DisableBracketedPaste = []byte{Esc, '[', '?', '2', '0', '0', '4', 'l'}
+
+// EnableBracketedPaste asks the terminal to bracket pastes
+// and collects them into Paste reports (see CollectPastes)
+func (a *Ansi) EnableBracketedPaste() {
+	a.CollectPastes(true)
+	a.Write(EnableBracketedPaste)
+}
+
+// DisableBracketedPaste stops the bracketing and collecting
+func (a *Ansi) DisableBracketedPaste() {
+	a.Write(DisableBracketedPaste)
+	a.CollectPastes(false)
+}
+
+// CollectPastes, when on, gathers everything between the
+// bracketed paste markers into a single Paste report, instead
+// of passing the pasted text through with the rest of the input.
+// Pastes are only bracketed once the terminal has been asked to.
+func (a *Ansi) CollectPastes(on bool) {
+	a.mu.Lock()
+	a.collect = on
+	a.mu.Unlock()
+}
+
+// pastes pulls any pasted text out of src
+// before passing the rest on to scan
+func (a *Ansi) pastes(src []byte) {
+	for len(src) > 0 {
+		if a.paste != nil {
+			i := bytes.Index(src, PasteEnd)
+			if i < 0 {
+				a.paste.Write(src)
+				return
+			}
+			a.paste.Write(src[:i])
+			a.report(&Report{Type: Paste, Text: a.paste.String()})
+			a.paste = nil
+			src = src[i+len(PasteEnd):]
+			continue
+		}
+		a.mu.Lock()
+		collect := a.collect
+		a.mu.Unlock()
+		i := -1
+		if collect {
+			i = bytes.Index(src, PasteStart)
+		}
+		if i < 0 {
+			a.forward(a.scan(src))
+			return
+		}
+		a.forward(a.scan(src[:i]))
+		a.paste = &bytes.Buffer{}
+		src = src[i+len(PasteStart):]
+	}
+}
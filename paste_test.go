@@ -0,0 +1,40 @@
+package ansi
+
+import "testing"
+
+func TestCollectPastes(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.CollectPastes(true)
+	go func() {
+		f.send("a\x1b[200~line one\n")
+		f.send("line \x1b[6n two\x1b[201~b")
+	}()
+	buf := make([]byte, 16)
+	if n, _ := a.Read(buf); string(buf[:n]) != "a" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	r := <-a.Reports
+	if r.Type != Paste || r.Text != "line one\nline \x1b[6n two" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if n, _ := a.Read(buf); string(buf[:n]) != "b" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+}
+
+func TestBracketedPaste(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.EnableBracketedPaste()
+	f.send("\x1b[200~pasted\x1b[201~")
+	if r := <-a.Reports; r.Type != Paste || r.Text != "pasted" {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	a.DisableBracketedPaste()
+	if got, want := f.written(), "\x1b[?2004h\x1b[?2004l"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package ansi
+
+import "io"
+
+// StripPipes, when on, removes the escape sequences from
+// everything copied by Pipe, leaving the plain text
+func (a *Ansi) StripPipes(on bool) {
+	a.mu.Lock()
+	a.strip = on
+	a.mu.Unlock()
+}
+
+// Pipe copies r to the terminal through a fixed size buffer, for
+// output too large to hold at once. It returns the number of bytes
+// read from r. Sequences split between reads are kept whole, when
+// stripping is on.
+func (a *Ansi) Pipe(r io.Reader) (int64, error) {
+	a.mu.Lock()
+	strip := a.strip
+	a.mu.Unlock()
+	buff := make([]byte, 32*1024)
+	var carry []byte
+	var total int64
+	for {
+		n, err := r.Read(buff)
+		total += int64(n)
+		chunk := buff[:n]
+		if strip {
+			chunk = append(carry, chunk...)
+			carry = nil
+			if i := incomplete(chunk); i >= 0 && err == nil {
+				carry = append([]byte(nil), chunk[i:]...)
+				chunk = chunk[:i]
+			}
+			chunk = Strip(chunk)
+		}
+		if len(chunk) > 0 {
+			if _, werr := a.Write(chunk); werr != nil {
+				return total, werr
+			}
+		}
+		if err == io.EOF {
+			return total, nil
+		} else if err != nil {
+			return total, err
+		}
+	}
+}
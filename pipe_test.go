@@ -0,0 +1,47 @@
+package ansi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestPipe(t *testing.T) {
+	in := strings.Repeat(Red.String("error")+" at "+string(Goto(1, 1))+"line\n", 10000)
+	for _, tc := range []struct {
+		strip bool
+		out   string
+	}{
+		{false, in},
+		{true, strings.Repeat("error at line\n", 10000)},
+	} {
+		var w writeLog
+		a := Wrap(&w)
+		a.StripPipes(tc.strip)
+		n, err := a.Pipe(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(len(in)) {
+			t.Errorf("strip %v: read %d bytes, expected %d", tc.strip, n, len(in))
+		}
+		if got := string(bytes.Join(w, nil)); got != tc.out {
+			t.Errorf("strip %v: wrote %d bytes, expected %d", tc.strip, len(got), len(tc.out))
+		}
+	}
+}
+
+func TestPipeSplit(t *testing.T) {
+	var w writeLog
+	a := Wrap(&w)
+	a.StripPipes(true)
+	//a byte at a time splits every sequence
+	in := Red.String("a") + "b" + Green.String("c")
+	if _, err := a.Pipe(iotest.OneByteReader(strings.NewReader(in))); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(bytes.Join(w, nil)); got != "abc" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,40 @@
+package ansi
+
+import (
+	"io"
+	"time"
+)
+
+// now is swapped out by tests
+var now = time.Now
+
+// progressInterval is the least time between progress redraws
+const progressInterval = 100 * time.Millisecond
+
+// ProgressWriter returns a writer which counts the bytes written
+// to it, redrawing the current line with render(written) on the
+// first write and then at most every progressInterval. Use it as
+// one side of an io.MultiWriter or io.TeeReader in a copy. The
+// last count may not be drawn, so draw the final line once done.
+func (a *Ansi) ProgressWriter(render func(written int64) []byte) io.Writer {
+	return &progress{a: a, render: render}
+}
+
+type progress struct {
+	a       *Ansi
+	render  func(int64) []byte
+	written int64
+	drawn   time.Time
+}
+
+func (p *progress) Write(b []byte) (int, error) {
+	p.written += int64(len(b))
+	if t := now(); p.drawn.IsZero() || t.Sub(p.drawn) >= progressInterval {
+		p.drawn = t
+		line := append([]byte{'\r'}, p.render(p.written)...)
+		if _, err := p.a.Write(append(line, EraseEndLine...)); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
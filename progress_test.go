@@ -0,0 +1,37 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressWriter(t *testing.T) {
+	clock := time.Unix(0, 0)
+	now = func() time.Time { return clock }
+	defer func() { now = time.Now }()
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	pw := a.ProgressWriter(func(n int64) []byte {
+		return []byte(strconv.FormatInt(n, 10) + " bytes")
+	})
+	var dst bytes.Buffer
+	w := io.MultiWriter(&dst, pw)
+	w.Write([]byte("abc"))
+	w.Write([]byte("de")) //too soon, not drawn
+	clock = clock.Add(progressInterval)
+	w.Write([]byte("fgh"))
+	if _, err := io.Copy(w, strings.NewReader("ij")); err != nil {
+		t.Fatal(err)
+	}
+	if dst.String() != "abcdefghij" {
+		t.Fatalf("expected the data to be copied, got %q", dst.String())
+	}
+	if got, want := f.written(), "\r3 bytes\x1b[K\r8 bytes\x1b[K"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
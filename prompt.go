@@ -0,0 +1,38 @@
+package ansi
+
+import "bytes"
+
+// Prompt writes label, styled with attrs, leaving the cursor after
+// it ready for input and shaped as a blinking bar. The shape the
+// cursor had is restored once a line has been read (when Read
+// returns a carriage return or newline).
+func (a *Ansi) Prompt(label string, attrs ...Attribute) error {
+	prev, err := a.QueryCursorStyle(positionTimeout)
+	if err != nil {
+		prev = DefaultCursor
+	}
+	b := []byte(WrapString(label, attrs...))
+	if prev != BlinkingBar {
+		b = append(b, SetCursorShape(BlinkingBar)...)
+		a.mu.Lock()
+		a.promptShape = &prev
+		a.mu.Unlock()
+	}
+	_, err = a.Write(b)
+	return err
+}
+
+// answered restores the cursor shape
+// once the data read ends a prompt
+func (a *Ansi) answered(data []byte) {
+	if !bytes.ContainsAny(data, "\r\n") {
+		return
+	}
+	a.mu.Lock()
+	shape := a.promptShape
+	a.promptShape = nil
+	a.mu.Unlock()
+	if shape != nil {
+		a.SetCursorShape(*shape)
+	}
+}
@@ -0,0 +1,31 @@
+package ansi
+
+import "testing"
+
+func TestPrompt(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == string(QuerySetting(" q")) {
+			return "\x1bP1$r2 q\x1b\\"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	if err := a.Prompt("name? ", Bright); err != nil {
+		t.Fatal(err)
+	}
+	written := len(f.written())
+	want := string(QuerySetting(" q")) + "\x1b[1mname? \x1b[0m" + "\x1b[5 q"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	go f.send("bob\r")
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "bob\r" {
+		t.Fatalf("unexpected data %q", buf[:n])
+	}
+	if got := f.written()[written:]; got != "\x1b[2 q" {
+		t.Fatalf("expected the cursor shape restored, got %q", got)
+	}
+}
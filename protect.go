@@ -0,0 +1,32 @@
+package ansi
+
+// Protect Area		<ESC>[1"q
+// Unprotect Area	<ESC>[0"q
+// Characters written while protected (DECSCA) survive the
+// selective erase sequences, like <ESC>[?K, though the regular
+// erase sequences still clear them.
+var ProtectArea = []byte{Esc, '[', '1', '"', 'q'}
+var UnprotectArea = []byte{Esc, '[', '0', '"', 'q'}
+
+func (a *Ansi) ProtectArea() {
+	a.Write(ProtectArea)
+}
+
+func (a *Ansi) UnprotectArea() {
+	a.Write(UnprotectArea)
+}
+
+// Selective Erase Line		<ESC>[?K
+// Selective Erase Screen	<ESC>[?J
+// These erase (from the cursor) only the characters which were not
+// written within ProtectArea and UnprotectArea.
+var SelectiveEraseLine = []byte{Esc, '[', '?', 'K'}
+var SelectiveEraseScreen = []byte{Esc, '[', '?', 'J'}
+
+func (a *Ansi) SelectiveEraseLine() {
+	a.Write(SelectiveEraseLine)
+}
+
+func (a *Ansi) SelectiveEraseScreen() {
+	a.Write(SelectiveEraseScreen)
+}
@@ -0,0 +1,26 @@
+package ansi
+
+import "testing"
+
+func TestProtectArea(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.ProtectArea()
+	a.Write([]byte("label"))
+	a.UnprotectArea()
+	if got := f.written(); got != "\x1b[1\"qlabel\x1b[0\"q" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestSelectiveErase(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.SelectiveEraseLine()
+	a.SelectiveEraseScreen()
+	if got := f.written(); got != "\x1b[?K\x1b[?J" {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package ansi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTimeout is returned by queries which
+// did not receive a report in time
+var ErrTimeout = errors.New("Timed out waiting for report")
+
+// waiter receives the reports matched by a pending query,
+// instead of them being sent on the Reports queue
+type waiter struct {
+	match   func(*Report) bool
+	reports chan *Report
+}
+
+// wait registers a waiter for reports matching match,
+// it must be released with unwait
+func (a *Ansi) wait(match func(*Report) bool) *waiter {
+	w := &waiter{match: match, reports: make(chan *Report, 8)}
+	a.mu.Lock()
+	a.waiters = append(a.waiters, w)
+	a.mu.Unlock()
+	return w
+}
+
+func (a *Ansi) unwait(w *waiter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, o := range a.waiters {
+		if o == w {
+			a.waiters = append(a.waiters[:i], a.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// report hands r to the first waiter that wants it,
+// otherwise it is placed on the Reports queue, or
+// inline in the read buffer when using ReadEvent
+func (a *Ansi) report(r *Report) {
+	a.mu.Lock()
+	if a.echo != nil {
+		fmt.Fprintln(a.echo, describe(r))
+	}
+	for _, w := range a.waiters {
+		if w.match(r) {
+			select {
+			case w.reports <- r:
+				a.mu.Unlock()
+				return
+			default:
+			}
+		}
+	}
+	inline, mice := a.inline, a.mice
+	a.mu.Unlock()
+	if mice != nil && r.Type == Mouse {
+		//like the queue, the oldest event makes way
+		e := r.MouseEvent()
+		for {
+			select {
+			case mice <- e:
+				return
+			default:
+			}
+			select {
+			case <-mice:
+			default:
+			}
+		}
+	}
+	if inline {
+		a.event(ReportEvent{Report: r})
+		return
+	}
+	a.queue(r)
+}
+
+// queue places r on the Reports queue, or passes it to the
+// OnReport func. When the queue is full the overflow policy
+// applies, by default the oldest report makes way so the
+// reader is never blocked.
+func (a *Ansi) queue(r *Report) {
+	a.mu.Lock()
+	onReport := a.onReport
+	a.mu.Unlock()
+	if onReport != nil {
+		onReport(r)
+		return
+	}
+	switch a.overflow {
+	case DropNewest:
+		select {
+		case a.Reports <- r:
+		default:
+		}
+		return
+	case Block:
+		select {
+		case a.Reports <- r:
+		case <-a.closed:
+		}
+		return
+	}
+	for {
+		select {
+		case a.Reports <- r:
+			return
+		default:
+		}
+		select {
+		case <-a.Reports:
+		default:
+		}
+	}
+}
+
+// query writes q and waits for the first matching report
+func (a *Ansi) query(q []byte, match func(*Report) bool, timeout time.Duration) (*Report, error) {
+	w := a.wait(match)
+	defer a.unwait(w)
+	if _, err := a.Write(q); err != nil {
+		return nil, err
+	}
+	select {
+	case r := <-w.reports:
+		return r, nil
+	case <-time.After(timeout):
+		return nil, ErrTimeout
+	case <-a.closed:
+		return nil, ErrClosed
+	}
+}
+
+// queryContext is query, waiting until ctx is done
+func (a *Ansi) queryContext(ctx context.Context, q []byte, match func(*Report) bool) (*Report, error) {
+	w := a.wait(match)
+	defer a.unwait(w)
+	if _, err := a.Write(q); err != nil {
+		return nil, err
+	}
+	select {
+	case r := <-w.reports:
+		return r, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-a.closed:
+		return nil, ErrClosed
+	}
+}
+
+// isType matches reports of type t
+func isType(t ReportType) func(*Report) bool {
+	return func(r *Report) bool {
+		return r.Type == t
+	}
+}
@@ -0,0 +1,67 @@
+package ansi
+
+import "errors"
+
+// ErrNotTerminal is returned by MakeRaw and Restore when
+// the wrapped stream is not a terminal
+var ErrNotTerminal = errors.New("Not a terminal")
+
+// fd returns the file descriptor of the wrapped stream,
+// when it has one (like an *os.File)
+func (a *Ansi) fd() (uintptr, bool) {
+	f, ok := a.rw.(interface{ Fd() uintptr })
+	if !ok {
+		return 0, false
+	}
+	return f.Fd(), true
+}
+
+// IsTerminal reports whether the wrapped stream is a terminal
+func (a *Ansi) IsTerminal() bool {
+	fd, ok := a.fd()
+	if !ok {
+		return false
+	}
+	_, err := getState(fd)
+	return err == nil
+}
+
+// MakeRaw puts the terminal in raw mode, input is read byte by byte
+// without echo or line editing and output is not processed. The
+// previous state is saved for Restore.
+func (a *Ansi) MakeRaw() error {
+	fd, ok := a.fd()
+	if !ok {
+		return ErrNotTerminal
+	}
+	state, err := getState(fd)
+	if err != nil {
+		return ErrNotTerminal
+	}
+	if err := setState(fd, makeRaw(state)); err != nil {
+		return err
+	}
+	a.mu.Lock()
+	if a.saved == nil {
+		a.saved = state
+	}
+	a.mu.Unlock()
+	return nil
+}
+
+// Restore returns the terminal to the state before MakeRaw,
+// it does nothing when MakeRaw was not called
+func (a *Ansi) Restore() error {
+	fd, ok := a.fd()
+	if !ok {
+		return ErrNotTerminal
+	}
+	a.mu.Lock()
+	state := a.saved
+	a.saved = nil
+	a.mu.Unlock()
+	if state == nil {
+		return nil
+	}
+	return setState(fd, state)
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package ansi
+
+type termState struct{}
+
+func getState(fd uintptr) (*termState, error) {
+	return nil, ErrNotTerminal
+}
+
+func setState(fd uintptr, t *termState) error {
+	return ErrNotTerminal
+}
+
+func makeRaw(t *termState) *termState {
+	return t
+}
@@ -0,0 +1,35 @@
+package ansi
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRawNotTerminal(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	if a.IsTerminal() {
+		t.Fatal("expected no terminal")
+	}
+	if err := a.MakeRaw(); err != ErrNotTerminal {
+		t.Fatalf("expected ErrNotTerminal, got %v", err)
+	}
+	if err := a.Restore(); err != ErrNotTerminal {
+		t.Fatalf("expected ErrNotTerminal, got %v", err)
+	}
+	//a file which is not a terminal
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	p := &Ansi{rw: r}
+	if p.IsTerminal() {
+		t.Fatal("expected a pipe not to be a terminal")
+	}
+	if err := p.MakeRaw(); err != ErrNotTerminal {
+		t.Fatalf("expected ErrNotTerminal, got %v", err)
+	}
+}
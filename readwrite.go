@@ -0,0 +1,55 @@
+package ansi
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrReadOnly is returned by the writes
+// of an Ansi made with NewReader
+var ErrReadOnly = errors.New("Ansi is read only")
+
+// NewWriter wraps w (like os.Stdout) for the write side helpers
+// only. Reads return io.EOF, so queries time out. Close closes w
+// when it is a Closer.
+func NewWriter(w io.Writer) *Ansi {
+	return Wrap(writeOnly{w})
+}
+
+// NewReader wraps r (like os.Stdin or a recorded session) for
+// parsing only. Writes fail with ErrReadOnly. Close closes r
+// when it is a Closer.
+func NewReader(r io.Reader) *Ansi {
+	return Wrap(readOnly{r})
+}
+
+type writeOnly struct {
+	io.Writer
+}
+
+func (writeOnly) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (w writeOnly) Close() error {
+	return closeIfCloser(w.Writer)
+}
+
+type readOnly struct {
+	io.Reader
+}
+
+func (readOnly) Write([]byte) (int, error) {
+	return 0, ErrReadOnly
+}
+
+func (r readOnly) Close() error {
+	return closeIfCloser(r.Reader)
+}
+
+func closeIfCloser(v interface{}) error {
+	if c, ok := v.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewWriter(t *testing.T) {
+	var buf bytes.Buffer
+	a := NewWriter(&buf)
+	a.Goto(2, 3)
+	a.Set(Red)
+	if got, want := buf.String(), "\x1b[2;3H\x1b[31m"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	if _, err := a.Read(make([]byte, 4)); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	a := NewReader(strings.NewReader("ab\x1b[12;34Rcd"))
+	defer a.Close()
+	var data []byte
+	buf := make([]byte, 8)
+	for {
+		n, err := a.Read(buf)
+		data = append(data, buf[:n]...)
+		if err != nil {
+			break
+		}
+	}
+	if string(data) != "abcd" {
+		t.Fatalf("unexpected data %q", data)
+	}
+	if r := <-a.Reports; r == nil || r.Type != Position || r.Pos.Row != 12 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+	if _, err := a.Write([]byte("x")); err != ErrReadOnly {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
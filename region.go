@@ -0,0 +1,43 @@
+package ansi
+
+import "bytes"
+
+// Region is a Viewport onto the screen of an Ansi, for split
+// pane layouts like a log area above a status bar
+type Region struct {
+	*Viewport
+	a *Ansi
+}
+
+// NewRegion returns a Region with its top left corner at top and
+// left (counting from 1, like Goto), height rows by width columns
+func (a *Ansi) NewRegion(top, left, height, width int) *Region {
+	return &Region{Viewport: NewViewport(a, top, left, height, width), a: a}
+}
+
+// Goto moves the region's cursor to row and col,
+// counting from 1 at its top left corner
+func (g *Region) Goto(row, col int) {
+	g.r, g.c = row-1, col-1
+	g.moved = true
+}
+
+// Clear blanks the region, in a single Write,
+// and moves its cursor to the top left corner
+func (g *Region) Clear() error {
+	var out []byte
+	if g.Width > 0 {
+		out = append(out, Set(Reset)...)
+		blank := bytes.Repeat([]byte{' '}, g.Width)
+		for r := 0; r < g.Height; r++ {
+			out = AppendGoto(out, uint16(g.Row+r), uint16(g.Col))
+			out = append(out, blank...)
+		}
+	}
+	g.Goto(1, 1)
+	if len(out) == 0 {
+		return nil
+	}
+	_, err := g.a.Write(out)
+	return err
+}
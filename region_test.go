@@ -0,0 +1,24 @@
+package ansi
+
+import "testing"
+
+func TestRegion(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	g := a.NewRegion(3, 5, 2, 4)
+	g.Write([]byte("abcdef\nxy\nclipped"))
+	g.Goto(2, 3)
+	g.Write([]byte("z"))
+	if err := g.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	g.Write([]byte("q"))
+	want := "\x1b[3;5Habcd\x1b[4;5Hxy" +
+		"\x1b[4;7Hz" +
+		"\x1b[0m\x1b[3;5H    \x1b[4;5H    " +
+		"\x1b[3;5Hq"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package ansi
+
+import "io"
+
+// Renderer draws screens to a terminal, writing only the cursor
+// moves, SGR sets and text needed to turn the frame it drew last
+// into the next one
+type Renderer struct {
+	w     io.Writer
+	cur   *Screen
+	style string
+	//cursor is zero based, known is
+	//false when the cursor is lost
+	cursor Point
+	known  bool
+}
+
+// NewRenderer returns a Renderer for a terminal of the given size,
+// which it takes to be blank with the cursor at the top left
+func NewRenderer(w io.Writer, rows, cols int) *Renderer {
+	return &Renderer{w: w, cur: NewScreen(rows, cols), known: true}
+}
+
+// Render writes the changes from the last frame to next, in a single
+// Write. A frame of another size redraws the whole screen, as does
+// the frame after a failed Write.
+func (r *Renderer) Render(next *Screen) error {
+	var out []byte
+	if next.Rows != r.cur.Rows || next.Cols != r.cur.Cols {
+		out = append(out, Set(Reset)...)
+		out = append(out, EraseScreen...)
+		out = append(out, CursorHome...)
+		r.cur, r.style = NewScreen(next.Rows, next.Cols), ""
+		r.cursor, r.known = Point{}, true
+	}
+	for _, ch := range next.Diff(r.cur) {
+		out = r.moveTo(out, next, ch.Row, ch.Col)
+		out = r.put(out, ch.New, next.Cols)
+	}
+	if len(out) > 0 {
+		if _, err := r.w.Write(out); err != nil {
+			//what the terminal got is unknown,
+			//so the next frame redraws it all
+			r.cur = &Screen{}
+			return err
+		}
+	}
+	for i := range r.cur.cells {
+		r.cur.cells[i] = next.Cell(i/next.Cols, i%next.Cols)
+	}
+	return nil
+}
+
+// moveTo appends the cheapest way to the cell at row, col: a cursor
+// move, or when on the row already, writing out the cells between
+// again if they share the current style
+func (r *Renderer) moveTo(out []byte, next *Screen, row, col int) []byte {
+	if r.known && r.cursor.Row == row && r.cursor.Col == col {
+		return out
+	}
+	var move []byte
+	if r.known {
+		move = MoveBetween(Point{r.cursor.Row + 1, r.cursor.Col + 1}, Point{row + 1, col + 1})
+	} else {
+		move = AppendGoto(nil, uint16(row+1), uint16(col+1))
+	}
+	if r.known && r.cursor.Row == row && col > r.cursor.Col && col-r.cursor.Col <= len(move) {
+		var gap []byte
+		for c := r.cursor.Col; c < col; c++ {
+			cell := next.Cell(row, c)
+			if cell.Style != r.style {
+				gap = nil
+				break
+			}
+			gap = append(gap, string(cell.Rune)...)
+		}
+		if gap != nil && len(gap) <= len(move) {
+			r.cursor.Col = col
+			return append(out, gap...)
+		}
+	}
+	r.cursor, r.known = Point{row, col}, true
+	return append(out, move...)
+}
+
+// put appends cell at the cursor, changing the style first when needed
+func (r *Renderer) put(out []byte, cell Cell, cols int) []byte {
+	if cell.Style != r.style {
+		if cell.Style == "" {
+			out = append(out, Set(Reset)...)
+		} else {
+			out = append(out, Set(Reset, Attribute(cell.Style))...)
+		}
+		r.style = cell.Style
+	}
+	out = append(out, string(cell.Rune)...)
+	//at the last column the cursor waits to wrap,
+	//where it is depends on the terminal
+	if r.cursor.Col++; r.cursor.Col >= cols {
+		r.known = false
+	}
+	return out
+}
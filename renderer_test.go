@@ -0,0 +1,80 @@
+package ansi
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestRenderer(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(&buf, 2, 6)
+	frame := NewScreen(2, 6)
+	frame.Write([]byte("ab  \x1b[31mcd"))
+	if err := r.Render(frame); err != nil {
+		t.Fatal(err)
+	}
+	//the two blanks are cheaper to write than to skip
+	if got, want := buf.String(), "ab  \x1b[0;31mcd"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+	buf.Reset()
+	if r.Render(frame); buf.Len() != 0 {
+		t.Fatalf("expected nothing for the same frame, got %q", buf.String())
+	}
+	next := NewScreen(2, 6)
+	next.Write([]byte("ab  \x1b[31mcd\x1b[0m\r\nxy"))
+	next.SetCell(0, 0, Cell{Rune: 'A'})
+	r.Render(next)
+	if got, want := buf.String(), "\x1b[1;1H\x1b[0mA\r\x1b[Bxy"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestRendererSize(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(&buf, 1, 1)
+	frame := NewScreen(1, 3)
+	frame.Write([]byte("\x1b[1mhi"))
+	r.Render(frame)
+	if got, want := buf.String(), "\x1b[0m\x1b[2J\x1b[H\x1b[0;1mhi"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestRendererWriteError(t *testing.T) {
+	r := NewRenderer(nopWriter{io.ErrClosedPipe}, 1, 3)
+	frame := NewScreen(1, 3)
+	frame.Write([]byte("hi"))
+	if err := r.Render(frame); err != io.ErrClosedPipe {
+		t.Fatalf("expected the write error, got %v", err)
+	}
+	var buf bytes.Buffer
+	r.w = &buf
+	if err := r.Render(frame); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "\x1b[0m\x1b[2J\x1b[Hhi"; got != want {
+		t.Fatalf("expected a redraw after the error, got %q, expected %q", got, want)
+	}
+}
+
+func TestRendererReplay(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRenderer(&buf, 3, 8)
+	term := NewScreen(3, 8)
+	for _, text := range []string{
+		"one\r\ntwo\r\nthree",
+		"\x1b[32mone\x1b[0m\r\n\r\nthree four",
+		"  x     \x1b[7my\r\n\x1b[3;8Hz",
+	} {
+		frame := NewScreen(3, 8)
+		frame.Write([]byte(text))
+		buf.Reset()
+		r.Render(frame)
+		term.Write(buf.Bytes())
+		if d := frame.Diff(term); len(d) != 0 {
+			t.Fatalf("after %q the terminal differs: %+v", text, d)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package ansi
+
+import "unicode/utf8"
+
+// worstCell is the most a cell costs to paint: a 24-bit foreground
+// and background set, followed by the longest UTF-8 rune
+var worstCell = len(Set(FGRGB(255, 255, 255), BGRGB(255, 255, 255))) + utf8.UTFMax
+
+// RepaintCost estimates the bytes a full repaint of a rows by cols
+// screen takes in the worst case, to weigh it against a diff. Each
+// row is assumed to be reached with a Goto and each cell to change
+// both colors with 24-bit values before a 4 byte rune, after which
+// the style is reset once. Real repaints are usually far smaller.
+func RepaintCost(rows, cols int) int {
+	if rows <= 0 || cols <= 0 {
+		return 0
+	}
+	n := 0
+	for r := 1; r <= rows; r++ {
+		n += len(Goto(uint16(r), 1))
+	}
+	return n + rows*cols*worstCell + len(Set(Reset))
+}
@@ -0,0 +1,17 @@
+package ansi
+
+import "testing"
+
+func TestRepaintCost(t *testing.T) {
+	for _, tc := range []struct {
+		rows, cols, cost int
+	}{
+		{0, 80, 0},
+		{1, 1, 6 + 40 + 4},
+		{24, 80, 9*6 + 15*7 + 24*80*40 + 4},
+	} {
+		if got := RepaintCost(tc.rows, tc.cols); got != tc.cost {
+			t.Errorf("RepaintCost(%d, %d) = %d, expected %d", tc.rows, tc.cols, got, tc.cost)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package ansi
+
+// Soft Reset		<ESC>[!p
+var SoftReset = []byte{Esc, '[', '!', 'p'}
+
+// ResetProfiles holds the sequence HardReset writes for each
+// terminal profile. Older xterms need the colors set back to
+// their defaults explicitly, others are simply reset (RIS).
+var ResetProfiles = map[string][]byte{
+	"xterm": append(Set(Reset), Set(Default, DefaultBG)...),
+	"vt100": ResetDevice,
+	"vt220": SoftReset,
+}
+
+// HardReset writes the reset sequence for the given profile,
+// unknown profiles get a full device reset (RIS)
+func (a *Ansi) HardReset(profile string) {
+	b, ok := ResetProfiles[profile]
+	if !ok {
+		b = ResetDevice
+	}
+	a.Write(b)
+}
+
+// Close Hyperlink		<ESC>]8;;<ESC>\
+// Reset Foreground Color	<ESC>]110<ESC>\
+// Reset Background Color	<ESC>]111<ESC>\
+var CloseHyperlink = []byte{Esc, ']', '8', ';', ';', Esc, '\\'}
+var ResetForeground = []byte{Esc, ']', '1', '1', '0', Esc, '\\'}
+var ResetBackground = []byte{Esc, ']', '1', '1', '1', Esc, '\\'}
+
+// FullReset resets the display attributes, closes any open
+// hyperlink and restores the default colors, for a clean teardown
+// after rich output. Unlike HardReset, the screen is untouched.
+func (a *Ansi) FullReset() {
+	b := append(Set(Reset), CloseHyperlink...)
+	b = append(b, ResetForeground...)
+	a.Write(append(b, ResetBackground...))
+}
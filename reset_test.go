@@ -0,0 +1,33 @@
+package ansi
+
+import "testing"
+
+func TestHardReset(t *testing.T) {
+	for _, tc := range []struct {
+		profile, out string
+	}{
+		{"xterm", "\x1b[0m\x1b[39;49m"},
+		{"vt220", "\x1b[!p"},
+		{"unknown", "\x1bc"},
+	} {
+		f := newFakeTerm()
+		a := Wrap(f)
+		a.HardReset(tc.profile)
+		if got := f.written(); got != tc.out {
+			t.Errorf("HardReset(%q) wrote %q, expected %q", tc.profile, got, tc.out)
+		}
+		a.Close()
+	}
+}
+
+func TestFullReset(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Hyperlink("https://example.com", "x")
+	n := len(f.written())
+	a.FullReset()
+	if got, want := f.written()[n:], "\x1b[0m\x1b]8;;\x1b\\\x1b]110\x1b\\\x1b]111\x1b\\"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
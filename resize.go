@@ -0,0 +1,102 @@
+package ansi
+
+import (
+	"sync"
+	"time"
+)
+
+// probeSize finds the size of the terminal by moving the cursor as
+// far as it will go and asking where it ended up. The cursor is
+// saved beforehand and restored afterwards.
+func (a *Ansi) probeSize(timeout time.Duration) (rows, cols int, err error) {
+	q := append([]byte{}, SaveAttrCursor...)
+	q = append(q, Goto(999, 999)...)
+	q = append(q, QueryCursorPosition...)
+	q = append(q, RestoreAttrCursor...)
+	r, err := a.query(q, isType(Position), timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	a.mu.Lock()
+	a.rows, a.cols = r.Pos.Row, r.Pos.Col
+	a.mu.Unlock()
+	return r.Pos.Row, r.Pos.Col, nil
+}
+
+// WatchResize probes the size of the terminal every interval, calling
+// onResize with the first size found and whenever it changes. This
+// works where there is no SIGWINCH, like over a network connection.
+// Call the returned func to stop watching, it is safe to call
+// repeatedly. Each probe waits up to interval for the terminal to
+// answer, see WatchResizeTimeout to wait less.
+func (a *Ansi) WatchResize(interval time.Duration, onResize func(rows, cols int)) (stop func()) {
+	return a.WatchResizeTimeout(interval, interval, onResize)
+}
+
+// WatchResizeTimeout is WatchResize, with each probe
+// waiting up to timeout for the terminal to answer
+func (a *Ansi) WatchResizeTimeout(interval, timeout time.Duration, onResize func(rows, cols int)) (stop func()) {
+	done := make(chan struct{})
+	tick, stopTicker := newTicker(interval)
+	go func() {
+		defer stopTicker()
+		rows, cols := 0, 0
+		for {
+			select {
+			case <-tick:
+			case <-done:
+				return
+			}
+			r, c, err := a.probeSize(timeout)
+			if err != nil || (r == rows && c == cols) {
+				continue
+			}
+			rows, cols = r, c
+			onResize(rows, cols)
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// Size is the size of the terminal
+type Size struct {
+	Rows, Cols int
+}
+
+// Size probes the size of the terminal, by moving the cursor into
+// the bottom right corner and asking for its position. This works
+// where the size cannot be asked of the system, like over a network
+// connection.
+func (a *Ansi) Size() (rows, cols int, err error) {
+	return a.probeSize(positionTimeout)
+}
+
+// PollResizes is WatchResizeTimeout, sending the sizes on Resizes
+func (a *Ansi) PollResizes(interval, timeout time.Duration) (stop func()) {
+	return a.WatchResizeTimeout(interval, timeout, a.Resized)
+}
+
+// Resized records a new size and sends it on Resizes, for
+// platform hooks like a SIGWINCH handler or the window change
+// request of an SSH session. Sizes nobody has received yet
+// make way for the new one.
+func (a *Ansi) Resized(rows, cols int) {
+	a.mu.Lock()
+	a.rows, a.cols = rows, cols
+	a.mu.Unlock()
+	s := Size{rows, cols}
+	for {
+		select {
+		case a.Resizes <- s:
+			return
+		default:
+		}
+		select {
+		case <-a.Resizes:
+		default:
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package ansi
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWatchResize(t *testing.T) {
+	tick := make(chan time.Time)
+	newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return tick, func() {}
+	}
+	defer func() { newTicker = defaultTicker }()
+
+	sizes := [][2]int{{24, 80}, {24, 80}, {30, 100}}
+	probes := 0
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		want := "\x1b7\x1b[999;999H\x1b[6n\x1b8"
+		if q != want {
+			t.Errorf("unexpected probe %q", q)
+		}
+		s := sizes[len(sizes)-1]
+		if probes < len(sizes) {
+			s = sizes[probes]
+		}
+		probes++
+		return fmt.Sprintf("\x1b[%d;%dR", s[0], s[1])
+	}
+	a := Wrap(f)
+	defer a.Close()
+	resized := make(chan [2]int, 3)
+	stop := a.WatchResize(time.Second, func(rows, cols int) {
+		resized <- [2]int{rows, cols}
+	})
+	defer stop()
+	for range sizes {
+		tick <- time.Now()
+	}
+	tick <- time.Now() //wait for the last probe
+	if s := <-resized; s != [2]int{24, 80} {
+		t.Fatalf("unexpected size %v", s)
+	}
+	if s := <-resized; s != [2]int{30, 100} {
+		t.Fatalf("unexpected size %v", s)
+	}
+	select {
+	case s := <-resized:
+		t.Fatalf("unexpected resize %v", s)
+	default:
+	}
+	stop()
+}
+
+func TestSize(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[40;120R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	rows, cols, err := a.Size()
+	if err != nil || rows != 40 || cols != 120 {
+		t.Fatalf("unexpected size %d,%d %v", rows, cols, err)
+	}
+}
+
+func TestResized(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Resized(24, 80)
+	a.Resized(30, 100)
+	if s := <-a.Resizes; s != (Size{30, 100}) {
+		t.Fatalf("expected the latest size, got %+v", s)
+	}
+	if a.rows != 30 || a.cols != 100 {
+		t.Fatalf("expected the size to be recorded, got %d,%d", a.rows, a.cols)
+	}
+}
+
+func TestPollResizes(t *testing.T) {
+	tick := make(chan time.Time)
+	newTicker = func(time.Duration) (<-chan time.Time, func()) {
+		return tick, func() {}
+	}
+	defer func() { newTicker = defaultTicker }()
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1b[50;60R"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	stop := a.PollResizes(time.Second, time.Second)
+	defer stop()
+	tick <- time.Now()
+	if s := <-a.Resizes; s != (Size{50, 60}) {
+		t.Fatalf("unexpected size %+v", s)
+	}
+}
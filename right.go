@@ -0,0 +1,26 @@
+package ansi
+
+// WriteRightAligned writes s styled with attrs on row, placed so
+// that it ends at the right edge of the terminal. The width of the
+// terminal is probed for when it is not yet known. Strings wider
+// than the terminal lose their leading columns.
+func (a *Ansi) WriteRightAligned(row int, s string, attrs ...Attribute) error {
+	a.mu.Lock()
+	cols := a.cols
+	a.mu.Unlock()
+	if cols == 0 {
+		var err error
+		if _, cols, err = a.probeSize(positionTimeout); err != nil {
+			return err
+		}
+	}
+	n := VisibleLength(s)
+	if n > cols {
+		s = VisibleSlice(s, n-cols, n)
+		n = cols
+	}
+	b := AppendGoto(nil, uint16(row), uint16(cols-n+1))
+	b = append(b, WrapString(s, attrs...)...)
+	_, err := a.Write(b)
+	return err
+}
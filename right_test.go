@@ -0,0 +1,29 @@
+package ansi
+
+import "testing"
+
+func TestWriteRightAligned(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.rows, a.cols = 24, 80
+	if err := a.WriteRightAligned(1, "12:00", Bright); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.written(), "\x1b[1;76H\x1b[1m12:00\x1b[0m"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestWriteRightAlignedTruncate(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.rows, a.cols = 24, 4
+	if err := a.WriteRightAligned(2, "abcdef"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.written(), "\x1b[2;1Hcdef"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package ansi
+
+import "strings"
+
+// Run is a piece of text with a single style
+type Run struct {
+	//Style holds the SGR parameters
+	//active for the text, "" when plain
+	Style string
+	Text  string
+}
+
+// SplitStyled splits s into runs of text by the style
+// applied to them. Escape sequences other than SGR
+// sets are dropped.
+func SplitStyled(s string) []Run {
+	var runs []Run
+	var active []string
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			if params, m := sgrParams(s[i:]); m > 0 {
+				for _, attr := range splitSGR(params) {
+					if attr == string(Reset) {
+						active = nil
+					} else {
+						active = append(active, attr)
+					}
+				}
+			}
+			i += n
+			continue
+		}
+		j := i + 1
+		for j < len(s) && s[j] != Esc {
+			j++
+		}
+		runs = append(runs, Run{Style: strings.Join(active, ";"), Text: s[i:j]})
+		i = j
+	}
+	return runs
+}
+
+// MergeRuns joins adjacent runs which share a style
+func MergeRuns(runs []Run) []Run {
+	var merged []Run
+	for _, r := range runs {
+		if n := len(merged); n > 0 && merged[n-1].Style == r.Style {
+			merged[n-1].Text += r.Text
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,36 @@
+package ansi
+
+import "testing"
+
+func TestSplitStyled(t *testing.T) {
+	got := SplitStyled("a" + Red.String("b") + string(Set(Bright, Green)) + "c" + string(Goto(1, 1)) + "d")
+	want := []Run{
+		{"", "a"},
+		{"31", "b"},
+		{"1;32", "c"},
+		{"1;32", "d"},
+	}
+	assertRuns(t, got, want)
+}
+
+func TestMergeRuns(t *testing.T) {
+	runs := SplitStyled(Red.String("a") + Red.String("b") + Green.String("c") + "d")
+	want := []Run{
+		{"31", "ab"},
+		{"32", "c"},
+		{"", "d"},
+	}
+	assertRuns(t, MergeRuns(runs), want)
+}
+
+func assertRuns(t *testing.T, got, want []Run) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	}
+}
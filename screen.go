@@ -0,0 +1,72 @@
+package ansi
+
+// Cell is a single character cell
+type Cell struct {
+	Rune rune
+	//Style holds the SGR parameters
+	//applied to the cell, "" when plain
+	Style string
+}
+
+// blank is the content of an empty cell
+var blank = Cell{Rune: ' '}
+
+// Screen is a grid of cells, rows and columns
+// are counted from zero
+type Screen struct {
+	Rows, Cols int
+	cells      []Cell
+	//terminal state for Write
+	row, col int
+	saved    [2]int
+	active   []Attribute
+	partial  []byte
+}
+
+// NewScreen returns a blank screen of the given size
+func NewScreen(rows, cols int) *Screen {
+	s := &Screen{Rows: rows, Cols: cols, cells: make([]Cell, rows*cols)}
+	for i := range s.cells {
+		s.cells[i] = blank
+	}
+	return s
+}
+
+// Cell returns the cell at row r and column c,
+// outside the screen all cells are blank
+func (s *Screen) Cell(r, c int) Cell {
+	if r < 0 || r >= s.Rows || c < 0 || c >= s.Cols {
+		return blank
+	}
+	return s.cells[r*s.Cols+c]
+}
+
+// SetCell replaces the cell at row r and column c,
+// cells outside the screen are ignored
+func (s *Screen) SetCell(r, c int, cell Cell) {
+	if r < 0 || r >= s.Rows || c < 0 || c >= s.Cols {
+		return
+	}
+	s.cells[r*s.Cols+c] = cell
+}
+
+// CellChange is a cell which differs between two screens
+type CellChange struct {
+	Row, Col int
+	Old, New Cell
+}
+
+// Diff returns the cells which differ from prev, in row
+// order. Cells prev does not have are compared as blank.
+func (s *Screen) Diff(prev *Screen) []CellChange {
+	var changes []CellChange
+	for r := 0; r < s.Rows; r++ {
+		for c := 0; c < s.Cols; c++ {
+			old, cur := prev.Cell(r, c), s.Cell(r, c)
+			if old != cur {
+				changes = append(changes, CellChange{Row: r, Col: c, Old: old, New: cur})
+			}
+		}
+	}
+	return changes
+}
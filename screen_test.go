@@ -0,0 +1,38 @@
+package ansi
+
+import "testing"
+
+func TestScreenDiff(t *testing.T) {
+	prev := NewScreen(2, 3)
+	prev.SetCell(0, 0, Cell{Rune: 'a'})
+	prev.SetCell(1, 2, Cell{Rune: 'z'})
+	next := NewScreen(2, 3)
+	next.SetCell(0, 0, Cell{Rune: 'a'})
+	next.SetCell(0, 1, Cell{Rune: 'b', Style: "31"})
+	next.SetCell(1, 2, Cell{Rune: 'z', Style: "1"})
+	want := []CellChange{
+		{Row: 0, Col: 1, Old: Cell{Rune: ' '}, New: Cell{Rune: 'b', Style: "31"}},
+		{Row: 1, Col: 2, Old: Cell{Rune: 'z'}, New: Cell{Rune: 'z', Style: "1"}},
+	}
+	got := next.Diff(prev)
+	if len(got) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %+v, got %+v", want, got)
+		}
+	}
+	if d := next.Diff(next); len(d) != 0 {
+		t.Fatalf("expected no changes, got %+v", d)
+	}
+}
+
+func TestScreenDiffSize(t *testing.T) {
+	next := NewScreen(2, 2)
+	next.SetCell(1, 1, Cell{Rune: 'x'})
+	got := next.Diff(NewScreen(1, 1))
+	if len(got) != 1 || got[0].Row != 1 || got[0].Col != 1 {
+		t.Fatalf("unexpected changes %+v", got)
+	}
+}
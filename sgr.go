@@ -0,0 +1,94 @@
+package ansi
+
+// Toggle is the state of an SGR flag, an Unset
+// flag leaves whatever was active before it
+type Toggle int8
+
+const (
+	Unset Toggle = iota
+	On
+	Off
+)
+
+// SGR is a style, with a field for each attribute so styles
+// can be merged. Unset flags and empty colors are unset, the
+// zero SGR changes nothing.
+type SGR struct {
+	FG, BG    Attribute
+	Bold      Toggle
+	Dim       Toggle
+	Italic    Toggle
+	Underline Toggle
+	Blink     Toggle
+	Reverse   Toggle
+	Hidden    Toggle
+}
+
+// ApplyDelta returns base with the set fields of delta
+// overriding its own, for styles inherited from a theme
+func ApplyDelta(base SGR, delta SGR) SGR {
+	if delta.FG != "" {
+		base.FG = delta.FG
+	}
+	if delta.BG != "" {
+		base.BG = delta.BG
+	}
+	toggle := func(b *Toggle, d Toggle) {
+		if d != Unset {
+			*b = d
+		}
+	}
+	toggle(&base.Bold, delta.Bold)
+	toggle(&base.Dim, delta.Dim)
+	toggle(&base.Italic, delta.Italic)
+	toggle(&base.Underline, delta.Underline)
+	toggle(&base.Blink, delta.Blink)
+	toggle(&base.Reverse, delta.Reverse)
+	toggle(&base.Hidden, delta.Hidden)
+	return base
+}
+
+// Attributes returns the attributes applying s, unset fields
+// are left out
+func (s SGR) Attributes() []Attribute {
+	var attrs []Attribute
+	//bold and dim are turned off together
+	if s.Bold == Off || s.Dim == Off {
+		attrs = append(attrs, "22")
+	}
+	for _, f := range []struct {
+		t       Toggle
+		on, off Attribute
+	}{
+		{s.Bold, Bright, ""},
+		{s.Dim, Dim, ""},
+		{s.Italic, Italic, "23"},
+		{s.Underline, Underscore, "24"},
+		{s.Blink, Blink, "25"},
+		{s.Reverse, Reverse, "27"},
+		{s.Hidden, Hidden, "28"},
+	} {
+		if f.t == On {
+			attrs = append(attrs, f.on)
+		} else if f.t == Off && f.off != "" {
+			attrs = append(attrs, f.off)
+		}
+	}
+	if s.FG != "" {
+		attrs = append(attrs, s.FG)
+	}
+	if s.BG != "" {
+		attrs = append(attrs, s.BG)
+	}
+	return attrs
+}
+
+// Bytes returns the Set sequence applying s,
+// or nothing when all of it is unset
+func (s SGR) Bytes() []byte {
+	attrs := s.Attributes()
+	if len(attrs) == 0 {
+		return nil
+	}
+	return Set(attrs...)
+}
@@ -0,0 +1,30 @@
+package ansi
+
+import "testing"
+
+func TestApplyDelta(t *testing.T) {
+	base := SGR{Bold: On, FG: Red}
+	got := ApplyDelta(base, SGR{BG: BlueBG})
+	if want := (SGR{Bold: On, FG: Red, BG: BlueBG}); got != want {
+		t.Fatalf("got %+v, expected %+v", got, want)
+	}
+	if b := string(got.Bytes()); b != "\x1b[1;31;44m" {
+		t.Fatalf("got %q", b)
+	}
+	got = ApplyDelta(got, SGR{Bold: Off, FG: Green})
+	if want := (SGR{Bold: Off, FG: Green, BG: BlueBG}); got != want {
+		t.Fatalf("got %+v, expected %+v", got, want)
+	}
+	if b := string(got.Bytes()); b != "\x1b[22;32;44m" {
+		t.Fatalf("got %q", b)
+	}
+}
+
+func TestSGRBytes(t *testing.T) {
+	if b := (SGR{}).Bytes(); b != nil {
+		t.Errorf("expected nothing for an unset style, got %q", b)
+	}
+	if b := string((SGR{Bold: On, Dim: Off, Italic: Off}).Bytes()); b != "\x1b[22;1;23m" {
+		t.Errorf("got %q", b)
+	}
+}
@@ -0,0 +1,60 @@
+package ansi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// VisibleSlice returns the part of s covering the visible
+// columns [from,to). Styling active at from is re-applied at
+// the start, and reset at the end if still active. Halves
+// of wide runes cut at either edge are replaced with spaces.
+func VisibleSlice(s string, from, to int) string {
+	var b strings.Builder
+	var active []string
+	started := false
+	start := func() {
+		if started {
+			return
+		}
+		started = true
+		if len(active) > 0 {
+			b.Write(Set(Attribute(strings.Join(active, ";"))))
+		}
+	}
+	col := 0
+	for i := 0; i < len(s) && col < to; {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			if params, m := sgrParams(s[i:]); m > 0 {
+				if params == string(Reset) {
+					active = nil
+				} else {
+					active = append(active, params)
+				}
+			}
+			if started {
+				b.WriteString(s[i : i+n])
+			}
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		w := runeWidth(r)
+		switch {
+		case col >= from && col+w <= to:
+			start()
+			b.WriteString(s[i : i+n])
+		case col < from && col+w > from, col >= from && col+w > to:
+			//wide rune cut by an edge
+			start()
+			b.WriteByte(' ')
+		}
+		col += w
+		i += n
+	}
+	if started && len(active) > 0 {
+		b.Write(Set(Reset))
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package ansi
+
+import "testing"
+
+func TestVisibleSlice(t *testing.T) {
+	line := "ab" + Red.String("cdef") + "gh"
+	for _, tc := range []struct {
+		from, to int
+		out      string
+	}{
+		{0, 2, "ab"},
+		{0, 3, "ab\x1b[31mc\x1b[0m"},
+		{3, 5, "\x1b[31mde\x1b[0m"},
+		{5, 8, "\x1b[31mf\x1b[0mgh"},
+		{6, 8, "gh"},
+		{6, 20, "gh"},
+	} {
+		if got := VisibleSlice(line, tc.from, tc.to); got != tc.out {
+			t.Errorf("VisibleSlice(%d, %d) = %q, expected %q", tc.from, tc.to, got, tc.out)
+		}
+	}
+}
+
+func TestVisibleSliceWide(t *testing.T) {
+	if got := VisibleSlice("a日本b", 2, 5); got != " 本" {
+		t.Errorf("got %q", got)
+	}
+	if got := VisibleSlice("a日本b", 0, 4); got != "a日 " {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	styled := Red.String("hello") + " 世界"
+	for _, tc := range []struct {
+		width int
+		out   string
+	}{
+		{20, styled},
+		{10, styled},
+		{9, Red.String("hello") + " 世 "},
+		{8, Red.String("hello") + " 世"},
+		{3, Red.String("hel")},
+		{0, ""},
+	} {
+		got := Truncate(styled, tc.width)
+		if got != tc.out {
+			t.Errorf("Truncate(%d) = %q, expected %q", tc.width, got, tc.out)
+		}
+		if n := Length(got); n > tc.width {
+			t.Errorf("Truncate(%d) is %d wide", tc.width, n)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package ansi
+
+import "math"
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a row of block characters scaled
+// between the smallest and largest value. When attrs are
+// provided the line is wrapped in them and a reset. A series
+// of equal values renders as a flat line of the lowest block.
+// NaN and -Inf render as the lowest block, +Inf as the highest.
+func Sparkline(values []float64, attrs ...Attribute) []byte {
+	if len(values) == 0 {
+		return nil
+	}
+	//non-finite values do not affect the scale
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			continue
+		}
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	top := len(sparks) - 1
+	line := make([]rune, len(values))
+	for i, v := range values {
+		n := 0
+		switch {
+		case math.IsInf(v, 1):
+			n = top
+		case max > min:
+			//clamp, NaN fails both comparisons and stays 0
+			if f := (v - min) / (max - min) * float64(top); f >= float64(top) {
+				n = top
+			} else if f > 0 {
+				n = int(f)
+			}
+		}
+		line[i] = sparks[n]
+	}
+	if len(attrs) == 0 {
+		return []byte(string(line))
+	}
+	b := append(Set(attrs...), string(line)...)
+	return append(b, Set(Reset)...)
+}
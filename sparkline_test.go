@@ -0,0 +1,26 @@
+package ansi
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSparkline(t *testing.T) {
+	for _, tc := range []struct {
+		values []float64
+		attrs  []Attribute
+		out    string
+	}{
+		{nil, nil, ""},
+		{[]float64{0, 1, 2, 3, 4, 5, 6, 7}, nil, "▁▂▃▄▅▆▇█"},
+		{[]float64{10, 20, 15}, nil, "▁█▄"},
+		{[]float64{3, 3, 3}, nil, "▁▁▁"},
+		{[]float64{1, 2}, []Attribute{Green}, "\x1b[32m▁█\x1b[0m"},
+		{[]float64{0, math.Inf(1), 7, math.NaN(), math.Inf(-1)}, nil, "▁██▁▁"},
+		{[]float64{math.NaN(), math.Inf(1)}, nil, "▁█"},
+	} {
+		if got := string(Sparkline(tc.values, tc.attrs...)); got != tc.out {
+			t.Errorf("Sparkline(%v) = %q, expected %q", tc.values, got, tc.out)
+		}
+	}
+}
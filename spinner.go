@@ -0,0 +1,35 @@
+package ansi
+
+// SpinnerFrames are the default frames of a Spinner
+var SpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner cycles through Frames, for callers which drive
+// their own render loop instead of running a goroutine
+type Spinner struct {
+	Frames []string
+	next   int
+}
+
+// NewSpinner returns a Spinner over frames,
+// or over SpinnerFrames when there are none
+func NewSpinner(frames ...string) *Spinner {
+	if len(frames) == 0 {
+		frames = SpinnerFrames
+	}
+	return &Spinner{Frames: frames}
+}
+
+// Frame returns the next frame followed by a move back over it,
+// so that writing each frame in turn animates in place
+func (s *Spinner) Frame() []byte {
+	if len(s.Frames) == 0 {
+		return nil
+	}
+	f := s.Frames[s.next%len(s.Frames)]
+	s.next = (s.next + 1) % len(s.Frames)
+	b := []byte(f)
+	if n := VisibleLength(f); n > 0 {
+		b = append(b, Backward(uint16(n))...)
+	}
+	return b
+}
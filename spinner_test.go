@@ -0,0 +1,20 @@
+package ansi
+
+import "testing"
+
+func TestSpinnerFrame(t *testing.T) {
+	s := NewSpinner("-", "\\", "|", "/")
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, string(s.Frame()))
+	}
+	want := []string{"-", "\\", "|", "/", "-", "\\"}
+	for i := range want {
+		if got[i] != want[i]+"\x1b[D" {
+			t.Fatalf("frame %d: got %q, expected %q", i, got[i], want[i]+"\x1b[D")
+		}
+	}
+	if n := len(NewSpinner().Frames); n != len(SpinnerFrames) {
+		t.Fatalf("expected the default frames, got %d", n)
+	}
+}
@@ -0,0 +1,51 @@
+package ansi
+
+// PushCursor queries the cursor position and saves it on a stack
+// kept by a, unlike SaveCursor which terminals keep a single slot
+// for, so pushes may be nested to any depth
+func (a *Ansi) PushCursor() error {
+	r, err := a.query(QueryCursorPosition, isType(Position), positionTimeout)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	a.cursors = append(a.cursors, [2]uint16{uint16(r.Pos.Row), uint16(r.Pos.Col)})
+	a.mu.Unlock()
+	return nil
+}
+
+// PopCursor moves the cursor back to the last pushed position
+// and removes it from the stack, it does nothing when empty
+func (a *Ansi) PopCursor() {
+	a.mu.Lock()
+	n := len(a.cursors)
+	if n == 0 {
+		a.mu.Unlock()
+		return
+	}
+	p := a.cursors[n-1]
+	a.cursors = a.cursors[:n-1]
+	a.mu.Unlock()
+	a.Goto(p[0], p[1])
+}
+
+// ScopedCursor enters and exits nested cursor scopes, each exit
+// returning the cursor to where the matching enter found it.
+// Scopes share the stack of PushCursor and PopCursor.
+type ScopedCursor struct {
+	a *Ansi
+}
+
+func (a *Ansi) ScopedCursor() *ScopedCursor {
+	return &ScopedCursor{a: a}
+}
+
+// Enter queries and saves the cursor position
+func (s *ScopedCursor) Enter() error {
+	return s.a.PushCursor()
+}
+
+// Exit moves the cursor back to where the last Enter found it
+func (s *ScopedCursor) Exit() {
+	s.a.PopCursor()
+}
@@ -0,0 +1,64 @@
+package ansi
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCursorStack(t *testing.T) {
+	f := newFakeTerm()
+	row := 0
+	f.reply = func(q string) string {
+		if q != string(QueryCursorPosition) {
+			return ""
+		}
+		row++
+		return fmt.Sprintf("\x1b[%d;%dR", row, row*10)
+	}
+	a := Wrap(f)
+	defer a.Close()
+	for i := 0; i < 3; i++ {
+		if err := a.PushCursor(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before := len(f.written())
+	for i := 0; i < 4; i++ {
+		a.PopCursor()
+	}
+	want := string(Goto(3, 30)) + string(Goto(2, 20)) + string(Goto(1, 10))
+	if got := f.written()[before:]; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestScopedCursor(t *testing.T) {
+	f := newFakeTerm()
+	row := 0
+	f.reply = func(q string) string {
+		if q != string(QueryCursorPosition) {
+			return ""
+		}
+		row++
+		return fmt.Sprintf("\x1b[%d;1R", row)
+	}
+	a := Wrap(f)
+	defer a.Close()
+	s := a.ScopedCursor()
+	var moves []string
+	exit := func() {
+		before := len(f.written())
+		s.Exit()
+		moves = append(moves, f.written()[before:])
+	}
+	s.Enter()
+	s.Enter()
+	exit()
+	s.Enter()
+	exit()
+	exit()
+	want := []string{string(Goto(2, 1)), string(Goto(3, 1)), string(Goto(1, 1))}
+	if fmt.Sprint(moves) != fmt.Sprint(want) {
+		t.Fatalf("got %q, expected %q", moves, want)
+	}
+}
@@ -0,0 +1,78 @@
+package ansi
+
+import "errors"
+
+// ErrNotSGR is returned by ParseSGR for anything
+// but a single complete SGR sequence
+var ErrNotSGR = errors.New("Not an SGR sequence")
+
+// ParseSGR returns the attributes set by the SGR sequence seq,
+// keeping extended colors (like 38;5;n) whole. A sequence
+// without parameters is a reset.
+func ParseSGR(seq []byte) ([]Attribute, error) {
+	params, n := sgrParams(string(seq))
+	if n == 0 || n != len(seq) {
+		return nil, ErrNotSGR
+	}
+	var attrs []Attribute
+	for _, attr := range splitSGR(params) {
+		attrs = append(attrs, Attribute(attr))
+	}
+	return attrs, nil
+}
+
+// State tracks the attributes active over a stream, written to it
+// or applied, so styling can be set again after a line or page
+// break. Colors replace the color before them, the off attributes
+// remove what they turn off and resets clear everything (like
+// ExtractAttributes).
+type State struct {
+	active  []Attribute
+	partial []byte
+}
+
+// Write tracks the SGR sequences in p, a sequence
+// split between writes is applied once complete
+func (s *State) Write(p []byte) (int, error) {
+	b := append(s.partial, p...)
+	s.partial = nil
+	if i := incomplete(b); i >= 0 {
+		s.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	str := string(b)
+	for i := 0; i < len(str); i++ {
+		if str[i] != Esc {
+			continue
+		}
+		if params, n := sgrParams(str[i:]); n > 0 {
+			for _, attr := range splitSGR(params) {
+				s.active = applyAttribute(s.active, Attribute(attr))
+			}
+		}
+	}
+	return len(p), nil
+}
+
+// Apply applies attrs in order
+func (s *State) Apply(attrs ...Attribute) {
+	for _, a := range attrs {
+		for _, attr := range splitSGR(string(a)) {
+			s.active = applyAttribute(s.active, Attribute(attr))
+		}
+	}
+}
+
+// Attributes returns the active attributes
+func (s *State) Attributes() []Attribute {
+	return append([]Attribute(nil), s.active...)
+}
+
+// Sequence returns the SGR sequence setting the active
+// attributes, nothing when none are
+func (s *State) Sequence() []byte {
+	if len(s.active) == 0 {
+		return nil
+	}
+	return Set(s.active...)
+}
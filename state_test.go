@@ -0,0 +1,38 @@
+package ansi
+
+import "testing"
+
+func TestParseSGR(t *testing.T) {
+	attrs, err := ParseSGR([]byte("\x1b[1;38;5;196;4m"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attrs) != 3 || attrs[0] != Bright || attrs[1] != "38;5;196" || attrs[2] != Underscore {
+		t.Fatalf("got %q", attrs)
+	}
+	if attrs, err := ParseSGR([]byte("\x1b[m")); err != nil || len(attrs) != 1 || attrs[0] != Reset {
+		t.Fatalf("got %q, %v", attrs, err)
+	}
+	for _, s := range []string{"\x1b[2J", "\x1b[31mx", "\x1b[31", "31"} {
+		if _, err := ParseSGR([]byte(s)); err != ErrNotSGR {
+			t.Errorf("%q: expected ErrNotSGR, got %v", s, err)
+		}
+	}
+}
+
+func TestState(t *testing.T) {
+	var s State
+	s.Write([]byte("\x1b[1;31mbold red\x1b[3"))
+	s.Write([]byte("2mgreen\x1b[22m"))
+	if got := string(s.Sequence()); got != "\x1b[32m" {
+		t.Fatalf("got %q", got)
+	}
+	s.Apply(Underscore, BlueBG)
+	if got := string(s.Sequence()); got != "\x1b[32;4;44m" {
+		t.Fatalf("got %q", got)
+	}
+	s.Write([]byte("\x1b[0m"))
+	if s.Sequence() != nil || len(s.Attributes()) != 0 {
+		t.Fatalf("expected nothing active, got %q", s.Attributes())
+	}
+}
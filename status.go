@@ -0,0 +1,31 @@
+package ansi
+
+// StatusLine draws text on the bottom row, styled with attrs, and
+// keeps the rest of the screen scrolling above it. The height of
+// the terminal is probed for when it is not yet known. The cursor
+// is saved beforehand and restored afterwards.
+func (a *Ansi) StatusLine(text string, attrs ...Attribute) error {
+	a.mu.Lock()
+	rows := a.rows
+	a.mu.Unlock()
+	if rows == 0 {
+		var err error
+		if rows, _, err = a.probeSize(positionTimeout); err != nil {
+			return err
+		}
+	}
+	b := append([]byte{}, SaveAttrCursor...)
+	b = append(b, Scroll(1, uint16(rows-1))...)
+	b = AppendGoto(b, uint16(rows), 1)
+	if len(attrs) > 0 {
+		b = AppendSet(b, attrs...)
+	}
+	b = append(b, text...)
+	b = append(b, EraseEndLine...)
+	if len(attrs) > 0 {
+		b = AppendSet(b, Reset)
+	}
+	b = append(b, RestoreAttrCursor...)
+	_, err := a.Write(b)
+	return err
+}
@@ -0,0 +1,17 @@
+package ansi
+
+import "testing"
+
+func TestStatusLine(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.rows, a.cols = 24, 80
+	if err := a.StatusLine("ready", Reverse); err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b7" + "\x1b[1;23r" + "\x1b[24;1H" + "\x1b[7mready\x1b[K\x1b[0m" + "\x1b8"
+	if got := f.written(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
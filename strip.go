@@ -0,0 +1,54 @@
+package ansi
+
+import "io"
+
+// Strip returns b without any escape sequences (SGR, cursor
+// moves, erases, private modes, reports, OSC and so on),
+// leaving only the visible text. b is not modified.
+func Strip(b []byte) []byte {
+	out := make([]byte, 0, len(b))
+	s := string(b)
+	for i := 0; i < len(s); {
+		if s[i] != Esc {
+			out = append(out, s[i])
+			i++
+			continue
+		}
+		n, _ := sequenceLen(s[i:])
+		i += n
+	}
+	return out
+}
+
+// StripString is Strip for strings
+func StripString(s string) string {
+	return string(Strip([]byte(s)))
+}
+
+// NewStripWriter returns a writer which passes what is written to
+// it on to w with Strip, for logging terminal output as plain text.
+// A sequence split between writes is still removed whole, however
+// long it is, like an OSC carrying a clipboard or an image.
+func NewStripWriter(w io.Writer) io.Writer {
+	return &stripWriter{w: w}
+}
+
+type stripWriter struct {
+	w       io.Writer
+	partial []byte
+}
+
+func (s *stripWriter) Write(p []byte) (int, error) {
+	b := append(s.partial, p...)
+	s.partial = nil
+	if i := unfinished(b, -1); i >= 0 {
+		s.partial = append([]byte(nil), b[i:]...)
+		b = b[:i]
+	}
+	if b = Strip(b); len(b) > 0 {
+		if _, err := s.w.Write(b); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,48 @@
+package ansi
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"plain", "plain"},
+		{string(Set(Green, BlueBG)) + "go" + string(Set(Reset)), "go"},
+		{Red.String("a") + string(Goto(2, 4)) + "b" + string(EraseLine), "ab"},
+		{"x\x1b[?25l\x1b[3Ay\x1b[2J\x1b[24;80R", "xy"},
+		{"\x1b]2;title\x1b\\t\x1b(0ext\x1b[", "text"},
+	} {
+		if got := StripString(tc.in); got != tc.out {
+			t.Errorf("StripString(%q) = %q, expected %q", tc.in, got, tc.out)
+		}
+	}
+}
+
+func TestStripWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewStripWriter(&b)
+	for _, p := range []string{"a\x1b[3", "1mb\x1b]2;ti", "tle\x07c", "\x1b"} {
+		if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+			t.Fatalf("unexpected %d %v", n, err)
+		}
+	}
+	if b.String() != "abc" {
+		t.Fatalf("got %q", b.String())
+	}
+}
+
+func TestStripWriterLongPayload(t *testing.T) {
+	var b bytes.Buffer
+	w := NewStripWriter(&b)
+	payload := strings.Repeat("A", 3*maxCarry)
+	for _, p := range []string{"a\x1b]52;c;", payload[:maxCarry], payload[maxCarry:], "\x07b"} {
+		w.Write([]byte(p))
+	}
+	if b.String() != "ab" {
+		t.Fatalf("expected the payload removed, got %d bytes", b.Len())
+	}
+}
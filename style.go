@@ -0,0 +1,90 @@
+package ansi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Style builds an SGR with chained calls, like
+// NewStyle().Bold().FG(Red).Sprint("hi")
+type Style struct {
+	SGR SGR
+}
+
+// NewStyle returns a Style with nothing set
+func NewStyle() Style {
+	return Style{}
+}
+
+func (s Style) Bold() Style {
+	s.SGR.Bold = On
+	return s
+}
+
+func (s Style) Dim() Style {
+	s.SGR.Dim = On
+	return s
+}
+
+func (s Style) Italic() Style {
+	s.SGR.Italic = On
+	return s
+}
+
+func (s Style) Underline() Style {
+	s.SGR.Underline = On
+	return s
+}
+
+func (s Style) Blink() Style {
+	s.SGR.Blink = On
+	return s
+}
+
+func (s Style) Reverse() Style {
+	s.SGR.Reverse = On
+	return s
+}
+
+func (s Style) Hidden() Style {
+	s.SGR.Hidden = On
+	return s
+}
+
+// FG sets the foreground color
+func (s Style) FG(color Attribute) Style {
+	s.SGR.FG = color
+	return s
+}
+
+// BG sets the background color, a foreground color
+// (like Red or RGB(...)) is used as the background
+func (s Style) BG(color Attribute) Style {
+	s.SGR.BG = background(color)
+	return s
+}
+
+// Sprint formats args like fmt.Sprint, styled and then reset
+func (s Style) Sprint(args ...interface{}) string {
+	return WrapString(fmt.Sprint(args...), s.SGR.Attributes()...)
+}
+
+// Sprintf formats like fmt.Sprintf, styled and then reset
+func (s Style) Sprintf(format string, args ...interface{}) string {
+	return WrapString(fmt.Sprintf(format, args...), s.SGR.Attributes()...)
+}
+
+// background returns the background form of a foreground color,
+// other attributes are returned as they are
+func background(a Attribute) Attribute {
+	s := string(a)
+	switch {
+	case strings.HasPrefix(s, "38;"):
+		return Attribute("48" + s[2:])
+	case len(s) == 2 && s[0] == '3' && s[1] >= '0' && s[1] <= '9':
+		return Attribute("4" + s[1:])
+	case len(s) == 2 && s[0] == '9' && s[1] >= '0' && s[1] <= '7':
+		return Attribute("10" + s[1:])
+	}
+	return a
+}
@@ -0,0 +1,26 @@
+package ansi
+
+import "testing"
+
+func TestStyle(t *testing.T) {
+	got := NewStyle().Bold().FG(Red).BG(RGB(30, 30, 30)).Sprint("hi")
+	if want := "\x1b[1;31;48;2;30;30;30mhi\x1b[0m"; got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+	got = NewStyle().Underline().BG(Blue).Sprintf("%d%%", 50)
+	if want := "\x1b[4;44m50%\x1b[0m"; got != want {
+		t.Errorf("got %q, expected %q", got, want)
+	}
+	if got := NewStyle().BG(BlueBG).BG(Color256(9)).Sprint("x"); got != "\x1b[48;5;9mx\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+	if got := NewStyle().Sprint("plain"); got != "plain" {
+		t.Errorf("expected no styling, got %q", got)
+	}
+	//chains do not share state
+	base := NewStyle().Bold()
+	base.Italic()
+	if got := base.Sprint("b"); got != "\x1b[1mb\x1b[0m" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+package ansi
+
+import "strings"
+
+// RenderTable draws rows as a bordered table. Column widths are
+// measured with VisibleLength so styled cells still line up.
+// Rows shorter than the widest row are padded with empty cells.
+func RenderTable(rows [][]string) []byte {
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if l := VisibleLength(cell); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+	var b strings.Builder
+	border := func() {
+		b.WriteByte('+')
+		for _, w := range widths {
+			b.WriteString(strings.Repeat("-", w+2))
+			b.WriteByte('+')
+		}
+		b.WriteByte('\n')
+	}
+	border()
+	for _, row := range rows {
+		b.WriteByte('|')
+		for i, w := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			b.WriteByte(' ')
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", w-VisibleLength(cell)+1))
+			b.WriteByte('|')
+		}
+		b.WriteByte('\n')
+	}
+	border()
+	return []byte(b.String())
+}
@@ -0,0 +1,56 @@
+package ansi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTable(t *testing.T) {
+	out := string(RenderTable([][]string{
+		{Green.String("ok"), "alpha"},
+		{"failed", Red.String("b")},
+		{"x"},
+	}))
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected 5 lines, got %d:\n%s", len(lines), out)
+	}
+	for i, l := range lines {
+		if w := VisibleLength(l); w != 18 {
+			t.Errorf("line %d: visible width %d, expected 18: %q", i, w, l)
+		}
+	}
+	if lines[0] != "+--------+-------+" {
+		t.Errorf("unexpected border %q", lines[0])
+	}
+	if lines[1] != "| "+Green.String("ok")+"     | alpha |" {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+	if lines[3] != "| x      |       |" {
+		t.Errorf("ragged row not padded %q", lines[3])
+	}
+}
+
+func TestVisibleLength(t *testing.T) {
+	for s, n := range map[string]int{
+		"":                       0,
+		"abc":                    3,
+		Red.String("abc"):        3,
+		string(Goto(1, 2)) + "x": 1,
+		"日本":                     4,
+	} {
+		if l := VisibleLength(s); l != n {
+			t.Errorf("VisibleLength(%q) = %d, expected %d", s, l, n)
+		}
+	}
+}
+
+func TestMaxVisibleWidth(t *testing.T) {
+	b := []byte(Red.String("short") + "\n" + Green.String("the longest") + Blue.String("!") + "\n\nmid line\n")
+	if n := MaxVisibleWidth(b); n != 12 {
+		t.Fatalf("MaxVisibleWidth = %d, expected 12", n)
+	}
+	if n := MaxVisibleWidth(nil); n != 0 {
+		t.Fatalf("MaxVisibleWidth(nil) = %d", n)
+	}
+}
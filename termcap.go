@@ -0,0 +1,81 @@
+package ansi
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Query Termcap		<ESC>P+q{hex name};...<ESC>\
+// Report Termcap		<ESC>P1+r{hex name}={hex value};...<ESC>\
+// Report Termcap Failure	<ESC>P0+r{hex name}<ESC>\
+func QueryTermcap(names ...string) []byte {
+	hexed := make([]string, len(names))
+	for i, n := range names {
+		hexed[i] = hex.EncodeToString([]byte(n))
+	}
+	b := append([]byte{Esc, 'P', '+', 'q'}, strings.Join(hexed, ";")...)
+	return append(b, Esc, '\\')
+}
+
+// QueryTermcap asks the terminal (XTGETTCAP) for the given
+// capabilities and returns those it knows. Terminals stop
+// answering at the first unknown name, so the result may
+// be partial. ErrTimeout is returned if nothing is heard.
+func (a *Ansi) QueryTermcap(names []string, timeout time.Duration) (map[string]string, error) {
+	//each name is only answered once
+	seen := map[string]bool{}
+	uniq := make([]string, 0, len(names))
+	for _, n := range names {
+		if !seen[n] {
+			seen[n] = true
+			uniq = append(uniq, n)
+		}
+	}
+	names = uniq
+	w := a.wait(isType(Termcap))
+	defer a.unwait(w)
+	if _, err := a.Write(QueryTermcap(names...)); err != nil {
+		return nil, err
+	}
+	caps := map[string]string{}
+	deadline := time.After(timeout)
+	for len(caps) < len(names) {
+		select {
+		case r := <-w.reports:
+			if r.Caps == nil {
+				return caps, nil
+			}
+			for k, v := range r.Caps {
+				caps[k] = v
+			}
+		case <-deadline:
+			if len(caps) == 0 {
+				return nil, ErrTimeout
+			}
+			return caps, nil
+		}
+	}
+	return caps, nil
+}
+
+// parseTermcap decodes the hex encoded name=value list
+func (a *Ansi) parseTermcap(valid bool, body string) *Report {
+	r := &Report{Type: Termcap}
+	if valid {
+		r.Caps = map[string]string{}
+		for _, c := range strings.Split(body, ";") {
+			kv := strings.SplitN(c, "=", 2)
+			k, err := hex.DecodeString(kv[0])
+			if err != nil || len(k) == 0 {
+				continue
+			}
+			v := []byte{}
+			if len(kv) == 2 {
+				v, _ = hex.DecodeString(kv[1])
+			}
+			r.Caps[string(k)] = string(v)
+		}
+	}
+	return r
+}
@@ -0,0 +1,42 @@
+package ansi
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+)
+
+func TestQueryTermcap(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q != string(QueryTermcap("TN", "Co")) {
+			t.Errorf("unexpected query %q", q)
+		}
+		tn := hex.EncodeToString([]byte("TN")) + "=" + hex.EncodeToString([]byte("xterm"))
+		co := hex.EncodeToString([]byte("Co")) + "=" + hex.EncodeToString([]byte("256"))
+		return "\x1bP1+r" + tn + "\x1b\\\x1bP1+r" + co + "\x1b\\"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	//duplicates are only asked once, and don't delay the result
+	caps, err := a.QueryTermcap([]string{"TN", "Co", "TN"}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps["TN"] != "xterm" || caps["Co"] != "256" {
+		t.Fatalf("unexpected caps %v", caps)
+	}
+}
+
+func TestQueryTermcapUnknown(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		return "\x1bP0+r" + hex.EncodeToString([]byte("zz")) + "\x1b\\"
+	}
+	a := Wrap(f)
+	defer a.Close()
+	caps, err := a.QueryTermcap([]string{"zz"}, time.Second)
+	if err != nil || len(caps) != 0 {
+		t.Fatalf("expected no caps, got %v %v", caps, err)
+	}
+}
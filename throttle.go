@@ -0,0 +1,53 @@
+package ansi
+
+import (
+	"io"
+	"time"
+)
+
+// sleep is swapped out by tests
+var sleep = time.Sleep
+
+// ThrottleWriter returns a writer which paces writes to w at
+// roughly bytesPerSec, in chunks of a tenth of a second. A rate
+// of zero or less returns w as is.
+func ThrottleWriter(w io.Writer, bytesPerSec int) io.Writer {
+	if bytesPerSec <= 0 {
+		return w
+	}
+	return &throttle{w: w, rate: bytesPerSec}
+}
+
+type throttle struct {
+	w    io.Writer
+	rate int
+}
+
+func (t *throttle) Write(p []byte) (n int, err error) {
+	chunk := t.rate / 10
+	if chunk == 0 {
+		chunk = 1
+	}
+	for len(p) > 0 {
+		c := chunk
+		if c > len(p) {
+			c = len(p)
+		}
+		m, err := t.w.Write(p[:c])
+		n += m
+		if err != nil {
+			return n, err
+		}
+		sleep(time.Duration(c) * time.Second / time.Duration(t.rate))
+		p = p[c:]
+	}
+	return n, nil
+}
+
+// Throttle paces all subsequent writes to bytesPerSec,
+// it should be called before the Ansi is in use
+func (a *Ansi) Throttle(bytesPerSec int) {
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	a.w = ThrottleWriter(a.w, bytesPerSec)
+}
@@ -0,0 +1,45 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestThrottleWriter(t *testing.T) {
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+	defer func() { sleep = time.Sleep }()
+
+	var out bytes.Buffer
+	w := ThrottleWriter(&out, 100)
+	n, err := w.Write(bytes.Repeat([]byte("x"), 25))
+	if n != 25 || err != nil || out.Len() != 25 {
+		t.Fatalf("wrote %d %v", n, err)
+	}
+	//10 byte chunks at 100 bytes/s
+	want := []time.Duration{100 * time.Millisecond, 100 * time.Millisecond, 50 * time.Millisecond}
+	if len(slept) != len(want) {
+		t.Fatalf("expected %v, slept %v", want, slept)
+	}
+	for i := range want {
+		if slept[i] != want[i] {
+			t.Fatalf("expected %v, slept %v", want, slept)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	var slept time.Duration
+	sleep = func(d time.Duration) { slept += d }
+	defer func() { sleep = time.Sleep }()
+
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.Throttle(1000)
+	a.Write(bytes.Repeat([]byte("x"), 500))
+	if slept != 500*time.Millisecond || len(f.written()) != 500 {
+		t.Fatalf("slept %v", slept)
+	}
+}
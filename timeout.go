@@ -0,0 +1,55 @@
+package ansi
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrWriteTimeout is returned by WriteTimeout when the
+// underlying writer has no deadlines and did not finish
+var ErrWriteTimeout = errors.New("Timed out writing")
+
+type writeDeadliner interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+// WriteTimeout writes p, giving up after d. Writers with deadlines
+// (like a net.Conn) have one set for the write, their own timeout
+// error is returned. Otherwise the write carries on in the
+// background and ErrWriteTimeout is returned, later writes wait
+// for it to finish.
+func (a *Ansi) WriteTimeout(p []byte, d time.Duration) (int, error) {
+	a.wmu.Lock()
+	if wd, ok := a.w.(writeDeadliner); ok {
+		defer a.wmu.Unlock()
+		select {
+		case <-a.closed:
+			//writeLocked returns ErrClosed
+		default:
+			if err := wd.SetWriteDeadline(time.Now().Add(d)); err != nil {
+				a.lastWriteErr = err
+				return 0, err
+			}
+			defer wd.SetWriteDeadline(time.Time{})
+		}
+		return a.writeLocked(p)
+	}
+	a.wmu.Unlock()
+	//the caller may reuse p once we return
+	p = append([]byte(nil), p...)
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := a.Write(p)
+		done <- result{n, err}
+	}()
+	select {
+	case r := <-done:
+		return r.n, r.err
+	case <-time.After(d):
+		return 0, ErrWriteTimeout
+	}
+}
@@ -0,0 +1,78 @@
+package ansi
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// blockingWriter never finishes a write until released,
+// then sends what was written on written, when set
+type blockingWriter struct {
+	release chan bool
+	written chan string
+}
+
+func (b *blockingWriter) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
+
+func (b *blockingWriter) Write(p []byte) (int, error) {
+	<-b.release
+	if b.written != nil {
+		b.written <- string(p)
+	}
+	return len(p), nil
+}
+
+func TestWriteTimeout(t *testing.T) {
+	w := &blockingWriter{release: make(chan bool)}
+	a := Wrap(w)
+	if _, err := a.WriteTimeout([]byte("x"), 10*time.Millisecond); err != ErrWriteTimeout {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	close(w.release)
+	if n, err := a.WriteTimeout([]byte("y"), time.Second); n != 1 || err != nil {
+		t.Fatalf("unexpected write %d %v", n, err)
+	}
+}
+
+func TestWriteTimeoutCopies(t *testing.T) {
+	w := &blockingWriter{release: make(chan bool), written: make(chan string, 1)}
+	a := Wrap(w)
+	p := []byte("x")
+	if _, err := a.WriteTimeout(p, 10*time.Millisecond); err != ErrWriteTimeout {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+	p[0] = 'y'
+	close(w.release)
+	if got := <-w.written; got != "x" {
+		t.Fatalf("expected the write to keep its bytes, got %q", got)
+	}
+}
+
+func TestWriteTimeoutClosed(t *testing.T) {
+	c, other := net.Pipe()
+	defer other.Close()
+	a := Wrap(c)
+	a.Close()
+	if _, err := a.WriteTimeout([]byte("x"), time.Second); err != ErrClosed {
+		t.Fatalf("expected ErrClosed, got %v", err)
+	}
+	if err := a.WriteErr(); err != ErrClosed {
+		t.Fatalf("expected the error kept, got %v", err)
+	}
+}
+
+func TestWriteTimeoutDeadline(t *testing.T) {
+	c, other := net.Pipe()
+	defer other.Close()
+	a := Wrap(c)
+	defer a.Close()
+	//nothing reads the other end
+	_, err := a.WriteTimeout([]byte("x"), 10*time.Millisecond)
+	if ne, ok := err.(net.Error); !ok || !ne.Timeout() {
+		t.Fatalf("expected a deadline timeout, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package ansi
+
+// Set Window Title	<ESC>]2;{title}<ESC>\
+func Title(s string) []byte {
+	b := append([]byte{Esc, ']', '2', ';'}, s...)
+	return append(b, Esc, '\\')
+}
+
+func (a *Ansi) SetTitle(s string) {
+	a.Write(Title(s))
+}
+
+// Set Icon Name and Window Title	<ESC>]0;{title}<ESC>\
+func TitleAndIcon(s string) []byte {
+	b := append([]byte{Esc, ']', '0', ';'}, s...)
+	return append(b, Esc, '\\')
+}
+
+func (a *Ansi) SetTitleAndIcon(s string) {
+	a.Write(TitleAndIcon(s))
+}
+
+// Push Title		<ESC>[22;0t
+// Pop Title		<ESC>[23;0t
+var PushTitle = []byte{Esc, '[', '2', '2', ';', '0', 't'}
+var PopTitle = []byte{Esc, '[', '2', '3', ';', '0', 't'}
+
+func (a *Ansi) PushTitle() {
+	a.Write(PushTitle)
+}
+
+func (a *Ansi) PopTitle() {
+	a.Write(PopTitle)
+}
+
+// WithTitle sets the title to s while fn runs, then restores the
+// previous one. Terminals without the title stack keep s.
+func (a *Ansi) WithTitle(s string, fn func()) {
+	a.Write(append(append([]byte{}, PushTitle...), Title(s)...))
+	defer a.PopTitle()
+	fn()
+}
@@ -0,0 +1,21 @@
+package ansi
+
+import "testing"
+
+func TestWithTitle(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.WithTitle("build", func() {
+		a.Write([]byte("x"))
+	})
+	if got, want := f.written(), "\x1b[22;0t\x1b]2;build\x1b\\x\x1b[23;0t"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestTitleAndIcon(t *testing.T) {
+	if got := string(TitleAndIcon("vim")); got != "\x1b]0;vim\x1b\\" {
+		t.Fatalf("got %q", got)
+	}
+}
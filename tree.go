@@ -0,0 +1,55 @@
+package ansi
+
+import "strings"
+
+// TreeNode is a node drawn by RenderTree, Attrs
+// overrides the style the TreeStyle gives it
+type TreeNode struct {
+	Name     string
+	Attrs    []Attribute
+	Children []TreeNode
+}
+
+// TreeStyle styles the parts of RenderTree,
+// empty fields are left unstyled
+type TreeStyle struct {
+	//Connectors styles the lines between nodes
+	Connectors []Attribute
+	//Branch styles nodes with children, Leaf the others
+	Branch, Leaf []Attribute
+}
+
+// RenderTree draws root and its descendants as a file tree,
+// one node per line with each level indented by its connectors
+func RenderTree(root TreeNode, attrs TreeStyle) []byte {
+	var b strings.Builder
+	b.WriteString(attrs.node(root))
+	b.WriteByte('\n')
+	renderChildren(&b, root.Children, "", attrs)
+	return []byte(b.String())
+}
+
+func renderChildren(b *strings.Builder, nodes []TreeNode, indent string, attrs TreeStyle) {
+	for i, n := range nodes {
+		connector, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			connector, next = "└── ", "    "
+		}
+		b.WriteString(WrapString(indent+connector, attrs.Connectors...))
+		b.WriteString(attrs.node(n))
+		b.WriteByte('\n')
+		renderChildren(b, n.Children, indent+next, attrs)
+	}
+}
+
+// node returns the styled name of n
+func (s TreeStyle) node(n TreeNode) string {
+	a := n.Attrs
+	if a == nil {
+		a = s.Leaf
+		if len(n.Children) > 0 {
+			a = s.Branch
+		}
+	}
+	return WrapString(n.Name, a...)
+}
@@ -0,0 +1,36 @@
+package ansi
+
+import "testing"
+
+func TestRenderTree(t *testing.T) {
+	root := TreeNode{Name: "src", Children: []TreeNode{
+		{Name: "cmd", Children: []TreeNode{
+			{Name: "main.go"},
+			{Name: "deep", Children: []TreeNode{{Name: "x.go"}}},
+		}},
+		{Name: "README.md"},
+	}}
+	want := "src\n" +
+		"├── cmd\n" +
+		"│   ├── main.go\n" +
+		"│   └── deep\n" +
+		"│       └── x.go\n" +
+		"└── README.md\n"
+	if got := string(RenderTree(root, TreeStyle{})); got != want {
+		t.Fatalf("got\n%s\nexpected\n%s", got, want)
+	}
+}
+
+func TestRenderTreeStyle(t *testing.T) {
+	root := TreeNode{Name: "a", Children: []TreeNode{
+		{Name: "b"},
+		{Name: "c", Attrs: []Attribute{Red}},
+	}}
+	style := TreeStyle{Connectors: []Attribute{Dim}, Branch: []Attribute{Blue}, Leaf: []Attribute{Green}}
+	want := Blue.String("a") + "\n" +
+		Dim.String("├── ") + Green.String("b") + "\n" +
+		Dim.String("└── ") + Red.String("c") + "\n"
+	if got := string(RenderTree(root, style)); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
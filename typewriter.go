@@ -0,0 +1,28 @@
+package ansi
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// WriteSlow writes s one rune at a time, pausing perRune
+// between each. Escape sequences are written whole and
+// without a pause, so styling takes effect immediately.
+func (a *Ansi) WriteSlow(s string, perRune time.Duration) error {
+	for i := 0; i < len(s); {
+		n := 0
+		if s[i] == Esc {
+			n, _ = sequenceLen(s[i:])
+		} else {
+			_, n = utf8.DecodeRuneInString(s[i:])
+		}
+		if _, err := a.Write([]byte(s[i : i+n])); err != nil {
+			return err
+		}
+		if s[i] != Esc {
+			sleep(perRune)
+		}
+		i += n
+	}
+	return nil
+}
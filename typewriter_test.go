@@ -0,0 +1,30 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWriteSlow(t *testing.T) {
+	pauses := 0
+	sleep = func(d time.Duration) { pauses++ }
+	defer func() { sleep = time.Sleep }()
+
+	var w writeLog
+	a := Wrap(&w)
+	if err := a.WriteSlow(Red.String("hé"), time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"\x1b[31m", "h", "é", "\x1b[0m"}
+	if len(w) != len(want) {
+		t.Fatalf("expected writes %q, got %q", want, w)
+	}
+	for i := range want {
+		if string(w[i]) != want[i] {
+			t.Fatalf("expected writes %q, got %q", want, w)
+		}
+	}
+	if pauses != 2 {
+		t.Fatalf("expected 2 pauses, got %d", pauses)
+	}
+}
@@ -0,0 +1,43 @@
+package ansi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// UnderlineStyle is the kind of line drawn by Underline
+type UnderlineStyle int
+
+const (
+	UnderlineNone UnderlineStyle = iota
+	UnderlineSingle
+	UnderlineDouble
+	UnderlineCurly
+	UnderlineDotted
+	UnderlineDashed
+)
+
+// Set Underline Style		<ESC>[4:{style}m
+// Set Underline Color		<ESC>[58:5:{n}m
+// Set Underline RGB Color	<ESC>[58:2::{r}:{g}:{b}m
+//
+// These use colon separated sub-parameters, terminals
+// without them ignore the attribute or fall back to a
+// plain underline.
+func Underline(style UnderlineStyle) Attribute {
+	return subParams("4", strconv.Itoa(int(style)))
+}
+
+func Underline256(n uint8) Attribute {
+	return subParams("58", "5", strconv.Itoa(int(n)))
+}
+
+func UnderlineRGB(r, g, b uint8) Attribute {
+	//the empty sub-parameter is the (unused) color space
+	return subParams("58", "2", "", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b)))
+}
+
+// subParams joins an attribute and its sub-parameters with colons
+func subParams(p ...string) Attribute {
+	return Attribute(strings.Join(p, ":"))
+}
@@ -0,0 +1,19 @@
+package ansi
+
+import "testing"
+
+func TestUnderline(t *testing.T) {
+	for _, tc := range []struct {
+		attrs []Attribute
+		out   string
+	}{
+		{[]Attribute{Underline(UnderlineCurly)}, "\x1b[4:3m"},
+		{[]Attribute{Underline(UnderlineNone)}, "\x1b[4:0m"},
+		{[]Attribute{Underline(UnderlineCurly), UnderlineRGB(255, 0, 10)}, "\x1b[4:3;58:2::255:0:10m"},
+		{[]Attribute{Red, Underline256(196)}, "\x1b[31;58:5:196m"},
+	} {
+		if got := string(Set(tc.attrs...)); got != tc.out {
+			t.Errorf("got %q, expected %q", got, tc.out)
+		}
+	}
+}
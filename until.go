@@ -0,0 +1,47 @@
+package ansi
+
+import "time"
+
+// ReadUntilReport reads until a report of type t arrives, returning
+// the data read before it along with the report. Other reports are
+// passed on to the Reports queue. This suits handshakes, where a
+// response is interleaved with data. If nothing is heard in time,
+// the data so far is returned with ErrTimeout. Reports read before
+// the call are not seen, it should closely follow the query.
+func (a *Ansi) ReadUntilReport(t ReportType, timeout time.Duration) ([]byte, *Report, error) {
+	//reports are needed in stream order
+	a.mu.Lock()
+	inline := a.inline
+	a.inline = true
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		a.inline = inline
+		a.mu.Unlock()
+	}()
+	data := a.leftover()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case e, open := <-a.rbuff:
+			if !open {
+				return data, nil, a.rerr
+			}
+			switch e := e.(type) {
+			case DataEvent:
+				a.answered(e.Bytes)
+				data = append(data, e.Bytes...)
+			case ReportEvent:
+				if e.Type == t {
+					return data, e.Report, nil
+				}
+				a.queue(e.Report)
+			}
+		case <-timer.C:
+			return data, nil, ErrTimeout
+		case <-a.closed:
+			return data, nil, ErrClosed
+		}
+	}
+}
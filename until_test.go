@@ -0,0 +1,45 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadUntilReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		f.send("ab\x1b[2;3Rcd")
+		f.send("ef\x1b[0ngh")
+	}()
+	data, r, err := a.ReadUntilReport(OK, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "abcdef" || r.Type != OK {
+		t.Fatalf("unexpected %q %+v", data, r)
+	}
+	if r := <-a.Reports; r.Type != Position {
+		t.Fatalf("expected the position report to be queued, got %+v", r)
+	}
+	buf := make([]byte, 8)
+	if n, _ := a.Read(buf); string(buf[:n]) != "gh" {
+		t.Fatalf("expected the rest to be left, got %q", buf[:n])
+	}
+}
+
+func TestReadUntilReportTimeout(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		f.send("ab")
+	}()
+	data, _, err := a.ReadUntilReport(OK, 50*time.Millisecond)
+	if err != ErrTimeout || string(data) != "ab" {
+		t.Fatalf("unexpected %q %v", data, err)
+	}
+}
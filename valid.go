@@ -0,0 +1,39 @@
+package ansi
+
+import "bytes"
+
+// IsValidSequence is true when b is exactly one complete escape
+// sequence, in one of the forms listed by sequenceLen, with
+// its parameter, intermediate and final bytes in range
+func IsValidSequence(b []byte) bool {
+	if len(b) < 2 || b[0] != Esc {
+		return false
+	}
+	switch b[1] {
+	case '[':
+		i := 2
+		for i < len(b) && b[i] >= 0x30 && b[i] <= 0x3f {
+			i++
+		}
+		for i < len(b) && b[i] >= 0x20 && b[i] <= 0x2f {
+			i++
+		}
+		return i == len(b)-1 && b[i] >= 0x40 && b[i] <= 0x7e
+	case ']', 'P', 'X', '^', '_':
+		body := b[2:]
+		switch {
+		case bytes.HasSuffix(body, []byte{Esc, '\\'}):
+			body = body[:len(body)-2]
+		case b[1] == ']' && bytes.HasSuffix(body, []byte{7}):
+			body = body[:len(body)-1]
+		default:
+			return false
+		}
+		return bytes.IndexByte(body, Esc) < 0 && bytes.IndexByte(body, 7) < 0
+	}
+	i := 1
+	for i < len(b) && b[i] >= 0x20 && b[i] <= 0x2f {
+		i++
+	}
+	return i == len(b)-1 && b[i] >= 0x30 && b[i] <= 0x7e
+}
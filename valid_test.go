@@ -0,0 +1,35 @@
+package ansi
+
+import "testing"
+
+func TestIsValidSequence(t *testing.T) {
+	for _, tc := range []struct {
+		seq   string
+		valid bool
+	}{
+		{string(Goto(2, 4)), true},
+		{string(Set(Green, BlueBG)), true},
+		{"\x1b[?25l", true},
+		{"\x1b[ q", true},
+		{string(Title("hi")), true},
+		{"\x1b]11;?\a", true},
+		{"\x1bP+q544e\x1b\\", true},
+		{"\x1bc", true},
+		{"\x1b(0", true},
+		{"", false},
+		{"\x1b", false},
+		{"x\x1b[m", false},
+		{"\x1b[31", false},
+		{"\x1b[31mx", false},
+		{"\x1b[3\x01m", false},
+		{"\x1b[ 1m", false},
+		{"\x1b]2;title", false},
+		{"\x1b]2;a\x1bb\a", false},
+		{"\x1bP+q\a", false},
+		{"\x1b(", false},
+	} {
+		if got := IsValidSequence([]byte(tc.seq)); got != tc.valid {
+			t.Errorf("IsValidSequence(%q) = %v, expected %v", tc.seq, got, tc.valid)
+		}
+	}
+}
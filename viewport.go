@@ -0,0 +1,105 @@
+package ansi
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Viewport writes to a rectangle of the screen. Text is clipped
+// to the rectangle, newlines move to the start of the next row
+// within it, and gotos (<ESC>[{ROW};{COLUMN}H or f) are taken
+// relative to its top left corner, as are tabs and backspaces.
+// SGR sets are passed through, all other escape sequences and
+// control characters are dropped.
+type Viewport struct {
+	w                       io.Writer
+	Row, Col, Height, Width int
+	//cursor, relative to the viewport
+	r, c  int
+	moved bool
+}
+
+// NewViewport returns a Viewport writing to w with its top left
+// corner at row and col (counting from 1, like Goto)
+func NewViewport(w io.Writer, row, col, height, width int) *Viewport {
+	return &Viewport{w: w, Row: row, Col: col, Height: height, Width: width, moved: true}
+}
+
+// Write p, clipped to the viewport
+func (v *Viewport) Write(p []byte) (int, error) {
+	var out []byte
+	s := string(p)
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			seq := s[i : i+n]
+			if _, m := sgrParams(seq); m > 0 {
+				out = append(out, seq...)
+			} else if f := seq[len(seq)-1]; len(seq) > 2 && seq[1] == '[' && (f == 'H' || f == 'f') {
+				v.r, v.c = 0, 0
+				p := strings.Split(seq[2:len(seq)-1], ";")
+				if n, err := strconv.Atoi(p[0]); err == nil && n > 0 {
+					v.r = n - 1
+				}
+				if len(p) > 1 {
+					if n, err := strconv.Atoi(p[1]); err == nil && n > 0 {
+						v.c = n - 1
+					}
+				}
+				v.moved = true
+			}
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		switch r {
+		case '\n':
+			v.r, v.c = v.r+1, 0
+			v.moved = true
+			continue
+		case '\r':
+			v.c = 0
+			v.moved = true
+			continue
+		case '\t':
+			//tab stops are relative to the viewport,
+			//the last one is its right edge
+			if v.c = nextTab(v.c); v.c > v.Width {
+				v.c = v.Width
+			}
+			v.moved = true
+			continue
+		case '\b':
+			if v.c > 0 {
+				v.c--
+			}
+			v.moved = true
+			continue
+		}
+		if r < ' ' || r == 0x7f {
+			//other controls would move the real cursor
+			continue
+		}
+		w := runeWidth(r)
+		if v.r >= v.Height || v.c+w > v.Width {
+			v.c += w
+			v.moved = true
+			continue
+		}
+		if v.moved {
+			out = AppendGoto(out, uint16(v.Row+v.r), uint16(v.Col+v.c))
+			v.moved = false
+		}
+		out = append(out, s[i-n:i]...)
+		v.c += w
+	}
+	if len(out) > 0 {
+		if _, err := v.w.Write(out); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,37 @@
+package ansi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestViewport(t *testing.T) {
+	var out bytes.Buffer
+	v := NewViewport(&out, 2, 3, 2, 4)
+	v.Write([]byte("abcdef\n" + Red.String("xy") + "\nzzz"))
+	want := string(Goto(2, 3)) + "abcd\x1b[31m" + string(Goto(3, 3)) + "xy\x1b[0m"
+	if got := out.String(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestViewportGoto(t *testing.T) {
+	var out bytes.Buffer
+	v := NewViewport(&out, 10, 10, 3, 3)
+	v.Write([]byte(string(Goto(2, 2)) + "ab" + string(Goto(5, 1)) + "c" + "\x1b[2J"))
+	if got, want := out.String(), string(Goto(11, 11))+"ab"; got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
+
+func TestViewportControls(t *testing.T) {
+	var out bytes.Buffer
+	v := NewViewport(&out, 1, 5, 2, 10)
+	//the second tab stops at the edge, clipping the "c"
+	v.Write([]byte("a\tb\tc\nxy\bz\a!"))
+	want := string(Goto(1, 5)) + "a" + string(Goto(1, 13)) + "b" +
+		string(Goto(2, 5)) + "xy" + string(Goto(2, 6)) + "z!"
+	if got := out.String(); got != want {
+		t.Fatalf("got %q, expected %q", got, want)
+	}
+}
@@ -0,0 +1,160 @@
+package ansi
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TabWidth is the distance between tab stops, used
+// when measuring text and tracking the cursor column
+var TabWidth = 8
+
+// VisibleLength returns the number of terminal columns s
+// occupies once rendered, ignoring escape sequences and
+// counting wide runes (CJK, emoji) as two columns
+func VisibleLength(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			l, _ := sequenceLen(s[i:])
+			i += l
+			continue
+		}
+		if s[i] == '\t' {
+			n = nextTab(n)
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// Length is VisibleLength
+func Length(s string) int {
+	return VisibleLength(s)
+}
+
+// Truncate cuts s down to width visible columns, keeping its
+// styling (see VisibleSlice). s is returned as is when it fits.
+func Truncate(s string, width int) string {
+	if VisibleLength(s) <= width {
+		return s
+	}
+	return VisibleSlice(s, 0, width)
+}
+
+// MaxVisibleWidth returns the VisibleLength of
+// the widest line in b, for sizing a box around it
+func MaxVisibleWidth(b []byte) int {
+	max := 0
+	for _, line := range strings.Split(string(b), "\n") {
+		if n := VisibleLength(line); n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// nextTab returns the tab stop after col
+func nextTab(col int) int {
+	if TabWidth <= 0 {
+		return col
+	}
+	return (col/TabWidth + 1) * TabWidth
+}
+
+// sequenceLen returns the length of the escape sequence at the
+// start of s. ok is false when s ends before the sequence does,
+// in which case n is len(s).
+//
+// CSI	<ESC>[{params}{intermediates}{final}
+// OSC	<ESC>]{text}<BEL> or <ESC>]{text}<ESC>\
+// DCS	<ESC>P{text}<ESC>\ (also SOS, PM and APC)
+// Other	<ESC>{intermediates}{final}
+func sequenceLen(s string) (n int, ok bool) {
+	if len(s) == 0 || s[0] != Esc {
+		return 0, false
+	}
+	if len(s) == 1 {
+		return 1, false
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if c := s[i]; c >= 0x40 && c <= 0x7e {
+				return i + 1, true
+			} else if c < 0x20 || c > 0x3f {
+				//not a parameter or intermediate byte, abort here
+				return i, true
+			}
+		}
+	case ']', 'P', 'X', '^', '_':
+		for i := 2; i < len(s); i++ {
+			if s[i] == 7 && s[1] == ']' {
+				return i + 1, true
+			}
+			if s[i] == Esc && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+	default:
+		for i := 1; i < len(s); i++ {
+			if c := s[i]; c >= 0x30 && c <= 0x7e {
+				return i + 1, true
+			} else if c < 0x20 || c > 0x2f {
+				return i, true
+			}
+		}
+	}
+	return len(s), false
+}
+
+// runeWidth returns the number of columns r occupies
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7f && r < 0xa0):
+		return 0
+	case r == 0x200b || r == 0x200c || r == 0x200d || r == 0xfeff:
+		return 0
+	case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+		return 0
+	}
+	for _, w := range wideRunes {
+		if r < w[0] {
+			break
+		}
+		if r <= w[1] {
+			return 2
+		}
+	}
+	return 1
+}
+
+// wideRunes holds the (sorted) east asian wide and
+// fullwidth ranges, along with the common emoji blocks
+var wideRunes = [][2]rune{
+	{0x1100, 0x115f},
+	{0x231a, 0x231b},
+	{0x2329, 0x232a},
+	{0x23e9, 0x23ec},
+	{0x2e80, 0x303e},
+	{0x3041, 0x33ff},
+	{0x3400, 0x4dbf},
+	{0x4e00, 0x9fff},
+	{0xa000, 0xa4cf},
+	{0xa960, 0xa97f},
+	{0xac00, 0xd7a3},
+	{0xf900, 0xfaff},
+	{0xfe10, 0xfe19},
+	{0xfe30, 0xfe6f},
+	{0xff00, 0xff60},
+	{0xffe0, 0xffe6},
+	{0x1f300, 0x1f64f},
+	{0x1f900, 0x1f9ff},
+	{0x20000, 0x2fffd},
+	{0x30000, 0x3fffd},
+}
@@ -0,0 +1,61 @@
+package ansi
+
+import "time"
+
+// Window reports are answers to the window manipulation
+// queries, Params[0] identifies the kind of report:
+// Query Window Position	<ESC>[13t
+// Report Window Position	<ESC>[3;{X};{Y}t
+// Query Pixel Size		<ESC>[14t
+// Report Pixel Size		<ESC>[4;{HEIGHT};{WIDTH}t
+// Query Cell Size		<ESC>[16t
+// Report Cell Size		<ESC>[6;{HEIGHT};{WIDTH}t
+// Query Window Size		<ESC>[18t
+// Report Window Size		<ESC>[8;{ROWS};{COLUMNS}t
+var QueryWindowPosition = []byte{Esc, '[', '1', '3', 't'}
+var QueryPixelSize = []byte{Esc, '[', '1', '4', 't'}
+var QueryCellSize = []byte{Esc, '[', '1', '6', 't'}
+var QueryWindowSize = []byte{Esc, '[', '1', '8', 't'}
+
+func (a *Ansi) QueryWindowPosition() {
+	a.Write(QueryWindowPosition)
+}
+
+func (a *Ansi) QueryPixelSize() {
+	a.Write(QueryPixelSize)
+}
+
+func (a *Ansi) QueryCellSize() {
+	a.Write(QueryCellSize)
+}
+
+func (a *Ansi) QueryWindowSize() {
+	a.Write(QueryWindowSize)
+}
+
+// PixelSize asks the terminal for the size of its text area in pixels
+func (a *Ansi) PixelSize(timeout time.Duration) (height, width int, err error) {
+	r, err := a.query(QueryPixelSize, isWindow(4), timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	return r.Params[1], r.Params[2], nil
+}
+
+// WindowSize asks the terminal for the size of its text area
+// in characters
+func (a *Ansi) WindowSize(timeout time.Duration) (rows, cols int, err error) {
+	r, err := a.query(QueryWindowSize, isWindow(8), timeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	return r.Params[1], r.Params[2], nil
+}
+
+// isWindow matches Window reports of kind n
+// which carry a height and a width
+func isWindow(n int) func(*Report) bool {
+	return func(r *Report) bool {
+		return r.Type == Window && len(r.Params) == 3 && r.Params[0] == n
+	}
+}
@@ -0,0 +1,56 @@
+package ansi
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPixelSize(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == string(QueryPixelSize) {
+			return "\x1b[4;600;800t"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	h, w, err := a.PixelSize(time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != 600 || w != 800 {
+		t.Fatalf("got %dx%d", h, w)
+	}
+}
+
+func TestWindowReport(t *testing.T) {
+	f := newFakeTerm()
+	a := Wrap(f)
+	defer a.Close()
+	a.QueryWindowSize()
+	if got := f.written(); got != "\x1b[18t" {
+		t.Fatalf("unexpected query %q", got)
+	}
+	go f.send("\x1b[8;24;80t")
+	r := <-a.Reports
+	if r.Type != Window || len(r.Params) != 3 || r.Params[0] != 8 || r.Params[1] != 24 || r.Params[2] != 80 {
+		t.Fatalf("unexpected report %+v", r)
+	}
+}
+
+func TestWindowSize(t *testing.T) {
+	f := newFakeTerm()
+	f.reply = func(q string) string {
+		if q == "\x1b[18t" {
+			return "\x1b[4;600;800t\x1b[8;24;80t"
+		}
+		return ""
+	}
+	a := Wrap(f)
+	defer a.Close()
+	rows, cols, err := a.WindowSize(time.Second)
+	if err != nil || rows != 24 || cols != 80 {
+		t.Fatalf("unexpected size %d,%d %v", rows, cols, err)
+	}
+}
@@ -0,0 +1,119 @@
+package ansi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// WordWrap wraps s to lines of at most width visible columns,
+// breaking at spaces and cutting words longer than a line.
+// Escape sequences take no room, and styling active at a break
+// is reset at the end of the line and set again on the next.
+// The name Wrap is taken by the ReadWriter wrapper.
+func WordWrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	var lines []string
+	var line []byte
+	var active []Attribute
+	col := 0
+	//the last space on the line, as a byte
+	//offset, its column and the styling there
+	space, spaceCol := -1, 0
+	var spaceActive []Attribute
+	end := func(text []byte, attrs []Attribute) {
+		if len(attrs) > 0 {
+			text = append(text, Set(Reset)...)
+		}
+		lines = append(lines, string(text))
+	}
+	start := func(attrs []Attribute) []byte {
+		if len(attrs) == 0 {
+			return nil
+		}
+		return append([]byte(nil), Set(attrs...)...)
+	}
+	for i := 0; i < len(s); {
+		if s[i] == Esc {
+			n, _ := sequenceLen(s[i:])
+			if params, m := sgrParams(s[i:]); m > 0 {
+				for _, attr := range splitSGR(params) {
+					active = applyAttribute(active, Attribute(attr))
+				}
+			}
+			line = append(line, s[i:i+n]...)
+			i += n
+			continue
+		}
+		r, n := utf8.DecodeRuneInString(s[i:])
+		i += n
+		switch {
+		case r == '\n':
+			end(line, active)
+			line, col, space = start(active), 0, -1
+			continue
+		case r == ' ' && col >= width:
+			//a space at the break is dropped
+			end(line, active)
+			line, col, space = start(active), 0, -1
+			continue
+		case r == ' ':
+			space, spaceCol = len(line), col
+			spaceActive = append([]Attribute(nil), active...)
+		}
+		if w := runeWidth(r); col+w > width && col > 0 {
+			if space >= 0 {
+				tail := line[space+1:]
+				end(line[:space], spaceActive)
+				line = append(start(spaceActive), tail...)
+				col -= spaceCol + 1
+			} else {
+				end(line, active)
+				line, col = start(active), 0
+			}
+			space = -1
+		}
+		line = append(line, string(r)...)
+		col += runeWidth(r)
+	}
+	lines = append(lines, string(line))
+	return strings.Join(lines, "\n")
+}
+
+// Alignment is the placement of text in a column
+type Alignment int
+
+// Alignments of Align
+const (
+	AlignLeft Alignment = iota
+	AlignRight
+	AlignCenter
+)
+
+// PadRight pads s with spaces on the right to width visible columns
+func PadRight(s string, width int) string {
+	return Align(s, width, AlignLeft)
+}
+
+// PadLeft pads s with spaces on the left to width visible columns
+func PadLeft(s string, width int) string {
+	return Align(s, width, AlignRight)
+}
+
+// Align pads s with spaces to width visible columns, placing it
+// to the left, right or center, where an odd space goes on the
+// right. s is returned as is when it is as wide already.
+func Align(s string, width int, align Alignment) string {
+	pad := width - VisibleLength(s)
+	if pad <= 0 {
+		return s
+	}
+	switch align {
+	case AlignRight:
+		return strings.Repeat(" ", pad) + s
+	case AlignCenter:
+		return strings.Repeat(" ", pad/2) + s + strings.Repeat(" ", pad-pad/2)
+	}
+	return s + strings.Repeat(" ", pad)
+}
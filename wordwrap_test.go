@@ -0,0 +1,40 @@
+package ansi
+
+import "testing"
+
+func TestWordWrap(t *testing.T) {
+	for _, tc := range []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"the quick brown fox", 10, "the quick\nbrown fox"},
+		{"the quick brown fox", 9, "the quick\nbrown fox"},
+		{"abcdefghij", 4, "abcd\nefgh\nij"},
+		{"one\ntwo three", 5, "one\ntwo\nthree"},
+		{"a \x1b[31mred word\x1b[0m here", 5, "a \x1b[31mred\x1b[0m\n\x1b[31mword\x1b[0m\nhere"},
+		{"\x1b[1mbold text", 4, "\x1b[1mbold\x1b[0m\n\x1b[1mtext"},
+		{"世界世界", 5, "世界\n世界"},
+		{"short", 0, "short"},
+	} {
+		if got := WordWrap(tc.s, tc.width); got != tc.want {
+			t.Errorf("WordWrap(%q, %d) = %q, expected %q", tc.s, tc.width, got, tc.want)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	red := Red.String("ab")
+	if got := PadRight(red, 4); got != red+"  " {
+		t.Errorf("got %q", got)
+	}
+	if got := PadLeft("ab", 4); got != "  ab" {
+		t.Errorf("got %q", got)
+	}
+	if got := Align("ab", 5, AlignCenter); got != " ab  " {
+		t.Errorf("got %q", got)
+	}
+	if got := Align("abcdef", 4, AlignRight); got != "abcdef" {
+		t.Errorf("got %q", got)
+	}
+}